@@ -1,6 +1,134 @@
 package filter
 
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
 // baseAcronyms preserves known acronyms during case conversion.
 var baseAcronyms = map[string]string{
 	"ID": "ID",
 }
+
+// CaseOptions configures the WithOptions variants of the case converters,
+// such as CamelizeWithOptions.
+type CaseOptions struct {
+	// Acronyms lists words that keep an exact spelling, such as "SKU",
+	// "GraphQL", or "iOS". Input matches them case-insensitively, and a
+	// match inside camelCase input such as "myGraphQLSchema" is split out
+	// as its own word; zero CaseOptions keep the word splitting of the
+	// plain converters. Entries are merged with the default acronyms; a
+	// later entry with the same letters overrides an earlier one or a
+	// default.
+	Acronyms []string
+	// ReplaceAcronyms uses Acronyms alone, without the default acronyms.
+	ReplaceAcronyms bool
+}
+
+// acronymSet is the acronym table a single conversion runs with.
+type acronymSet struct {
+	spellings map[string]string // canonical spelling keyed by upper case
+	words     []string          // canonical spellings, longest first
+	// custom marks a set built from CaseOptions. Only a custom set splits
+	// acronyms out of longer runs of text, as in "myGraphQLSchema", and
+	// lowercases an acronym that starts camelCase output, as in "apiKey".
+	// The default set leaves both off, so the converters called without
+	// CaseOptions behave exactly as they always have.
+	custom bool
+}
+
+// defaultAcronyms is the read-only table used when no CaseOptions are given.
+var defaultAcronyms = newAcronymSet(nil, false)
+
+func newAcronymSet(extra []string, replace bool) *acronymSet {
+	set := &acronymSet{spellings: make(map[string]string, len(baseAcronyms)+len(extra))}
+	if !replace {
+		for key, spelling := range baseAcronyms {
+			set.spellings[key] = spelling
+		}
+	}
+	for _, spelling := range extra {
+		if spelling = strings.TrimSpace(spelling); spelling != "" {
+			set.spellings[strings.ToUpper(spelling)] = spelling
+		}
+	}
+	for _, spelling := range set.spellings {
+		set.words = append(set.words, spelling)
+	}
+	slices.SortFunc(set.words, func(a, b string) int {
+		if c := cmp.Compare(utf8.RuneCountInString(b), utf8.RuneCountInString(a)); c != 0 {
+			return c
+		}
+		return strings.Compare(a, b)
+	})
+	return set
+}
+
+func (o CaseOptions) acronymSet() *acronymSet {
+	if len(o.Acronyms) == 0 && !o.ReplaceAcronyms {
+		return defaultAcronyms
+	}
+	set := newAcronymSet(o.Acronyms, o.ReplaceAcronyms)
+	set.custom = true
+	return set
+}
+
+// lookup returns the canonical spelling of word when it is an acronym.
+func (a *acronymSet) lookup(word string) (string, bool) {
+	spelling, ok := a.spellings[strings.ToUpper(word)]
+	return spelling, ok
+}
+
+// matchAt reports the byte length and canonical spelling of the longest
+// acronym at the start of s that ends on a word boundary, or 0 when none
+// does. A match is a whole word when it is followed by the end of input, a
+// character that is neither a letter nor a digit, another acronym, or an
+// upper-case letter that starts a capitalized word ("SKUList"). Lower-case
+// letters and digits continue the word, so "identity" does not match "ID".
+func (a *acronymSet) matchAt(s string) (int, string) {
+	for _, word := range a.words {
+		n := prefixLen(s, utf8.RuneCountInString(word))
+		if n == 0 || !strings.EqualFold(s[:n], word) {
+			continue
+		}
+		if a.endsWord(s[n:]) {
+			return n, word
+		}
+	}
+	return 0, ""
+}
+
+func (a *acronymSet) endsWord(rest string) bool {
+	if rest == "" {
+		return true
+	}
+	r, size := utf8.DecodeRuneInString(rest)
+	switch {
+	case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+		return true
+	case !unicode.IsUpper(r):
+		return false
+	}
+	if next, _ := utf8.DecodeRuneInString(rest[size:]); unicode.IsLower(next) {
+		return true
+	}
+	n, _ := a.matchAt(rest)
+	return n > 0
+}
+
+// prefixLen returns the byte length of the first runes runes of s, or 0 when
+// s is shorter.
+func prefixLen(s string, runes int) int {
+	n := 0
+	for range runes {
+		if n >= len(s) {
+			return 0
+		}
+		_, size := utf8.DecodeRuneInString(s[n:])
+		n += size
+	}
+	return n
+}
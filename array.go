@@ -349,6 +349,34 @@ func SortNatural(input any, key ...string) ([]any, error) {
 	return out, nil
 }
 
+// SortNaturalOptions configures SortNaturalWithOptions. The zero value
+// behaves exactly like SortNatural.
+type SortNaturalOptions struct {
+	// CaseFold compares strings with CaseFold instead of lowercasing them,
+	// so "Straße" sorts with "STRASSE" and canonically equivalent accents
+	// compare equal.
+	CaseFold bool
+}
+
+// SortNaturalWithOptions is SortNatural with the string comparison selected
+// by opts.
+func SortNaturalWithOptions(input any, opts SortNaturalOptions, key ...string) ([]any, error) {
+	if !opts.CaseFold {
+		return SortNatural(input, key...)
+	}
+	slice, err := toSlice(input)
+	if err != nil {
+		return nil, err
+	}
+	out := slices.Clone(slice)
+	lookupKey, hasKey := optionalLookupKey(key...)
+	slices.SortStableFunc(out, func(a, b any) int {
+		a, b = sortValues(a, b, lookupKey, hasKey)
+		return compareValuesBy(a, b, CaseFold)
+	})
+	return out, nil
+}
+
 // Compact removes nil elements. If key is provided, removes items where the
 // property is nil or unreachable.
 func Compact(input any, key ...string) ([]any, error) {
@@ -410,6 +438,70 @@ func Reject(input any, key string, value ...any) ([]any, error) {
 	return out, nil
 }
 
+// WhereGlob returns items whose property at key matches the wildcard
+// pattern, using the rules of Glob. An empty key matches each item itself.
+// Values are compared in their fmt.Sprint form; missing and nil values never
+// match. A malformed pattern returns *Error{Kind: KindFormat}.
+func WhereGlob(input any, key, pattern string) ([]any, error) {
+	slice, err := toSlice(input)
+	if err != nil {
+		return nil, err
+	}
+	glob, err := compileGlob(pattern)
+	if err != nil {
+		return nil, formatErr("WhereGlob", err)
+	}
+	lookupKey, hasKey := optionalLookupKey(key)
+	out := make([]any, 0, len(slice))
+	for _, item := range slice {
+		if s, ok := stringAt(item, lookupKey, hasKey); ok && glob.match(s) {
+			out = append(out, item)
+		}
+	}
+	return out, nil
+}
+
+// WhereSimilar returns items whose property at key has a Similarity of at
+// least threshold to target, compared case-insensitively so that typos are
+// the only difference that counts. An empty key compares each item itself.
+// Values are compared in their fmt.Sprint form; missing and nil values never
+// match. The input order is kept. A threshold outside [0, 1] returns
+// *Error{Kind: KindInvalidInput}.
+func WhereSimilar(input any, key, target string, threshold float64) ([]any, error) {
+	slice, err := toSlice(input)
+	if err != nil {
+		return nil, err
+	}
+	if !(threshold >= 0 && threshold <= 1) {
+		return nil, invalidInput("WhereSimilar", fmt.Errorf("threshold %v outside [0, 1]", threshold))
+	}
+	target = strings.ToLower(target)
+	lookupKey, hasKey := optionalLookupKey(key)
+	out := make([]any, 0, len(slice))
+	for _, item := range slice {
+		if s, ok := stringAt(item, lookupKey, hasKey); ok && Similarity(strings.ToLower(s), target) >= threshold {
+			out = append(out, item)
+		}
+	}
+	return out, nil
+}
+
+// stringAt returns the fmt.Sprint form of item's property at key, or of item
+// itself without a key. Missing and nil values report false.
+func stringAt(item any, key lookupKey, hasKey bool) (string, bool) {
+	v := item
+	if hasKey {
+		var ok bool
+		if v, ok = lookupValue(item, key); !ok {
+			return "", false
+		}
+	}
+	if v == nil {
+		return "", false
+	}
+	return fmt.Sprint(v), true
+}
+
 // Find returns the first item whose property at key equals value, or
 // *Error{Kind: KindNotFound} if none matches.
 func Find(input any, key string, value any) (any, error) {
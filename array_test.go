@@ -2,6 +2,7 @@ package filter
 
 import (
 	"fmt"
+	"math"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -1387,6 +1388,69 @@ func TestSortNatural(t *testing.T) {
 	}
 }
 
+func TestSortNaturalWithOptions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input any
+		opts  SortNaturalOptions
+		key   []string
+		want  []any
+	}{
+		{
+			name:  "Zero value matches SortNatural",
+			input: []any{"Straße", "STRASSE", "apple"},
+			want:  []any{"apple", "STRASSE", "Straße"},
+		},
+		{
+			name:  "Case fold treats sharp s as ss",
+			input: []any{"Straße", "STRASSE", "apple"},
+			opts:  SortNaturalOptions{CaseFold: true},
+			want:  []any{"apple", "Straße", "STRASSE"},
+		},
+		{
+			name:  "Case fold equates composed and decomposed accents",
+			input: []any{"éclair", "ezra", "e\u0301clair", "Éclair"},
+			opts:  SortNaturalOptions{CaseFold: true},
+			want:  []any{"ezra", "éclair", "e\u0301clair", "Éclair"},
+		},
+		{
+			name:  "Numbers still sort numerically",
+			input: []any{"10", "2", "b", "A"},
+			opts:  SortNaturalOptions{CaseFold: true},
+			want:  []any{"2", "10", "A", "b"},
+		},
+		{
+			name: "Sort by key",
+			input: []any{
+				map[string]any{"name": "ΣΊΣΥΦΟΣ"},
+				map[string]any{"name": "Άλφα"},
+			},
+			opts: SortNaturalOptions{CaseFold: true},
+			key:  []string{"name"},
+			want: []any{
+				map[string]any{"name": "Άλφα"},
+				map[string]any{"name": "ΣΊΣΥΦΟΣ"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := SortNaturalWithOptions(tt.input, tt.opts, tt.key...)
+			require.NoError(t, err)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("SortNaturalWithOptions() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	_, err := SortNaturalWithOptions("not a slice", SortNaturalOptions{CaseFold: true})
+	require.ErrorIs(t, err, ErrInvalidInput)
+}
+
 func TestCompact(t *testing.T) {
 	t.Parallel()
 
@@ -1578,6 +1642,121 @@ func TestWhere(t *testing.T) {
 	}
 }
 
+func TestWhereGlob(t *testing.T) {
+	t.Parallel()
+
+	invoices := []any{
+		map[string]any{"number": "inv-2024-0001", "file": "jan.pdf"},
+		map[string]any{"number": "inv-2023-0099", "file": "dec.PDF"},
+		map[string]any{"number": "inv-2024-0002", "file": "feb.docx"},
+		map[string]any{"file": "missing-number.pdf"},
+		map[string]any{"number": nil, "file": "nil-number.pdf"},
+	}
+
+	tests := []struct {
+		name    string
+		input   any
+		key     string
+		pattern string
+		want    []any
+	}{
+		{
+			name:    "Prefix Pattern",
+			input:   invoices,
+			key:     "number",
+			pattern: "inv-2024-*",
+			want:    []any{invoices[0], invoices[2]},
+		},
+		{
+			name:    "Extension Is Case Sensitive",
+			input:   invoices,
+			key:     "file",
+			pattern: "*.pdf",
+			want:    []any{invoices[0], invoices[3], invoices[4]},
+		},
+		{
+			name:    "Character Class",
+			input:   invoices,
+			key:     "file",
+			pattern: "*.[pP][dD][fF]",
+			want:    []any{invoices[0], invoices[1], invoices[3], invoices[4]},
+		},
+		{
+			name:    "Empty Key Matches Items",
+			input:   []string{"a.pdf", "b.txt", "c.pdf"},
+			pattern: "*.pdf",
+			want:    []any{"a.pdf", "c.pdf"},
+		},
+		{
+			name:    "Non String Values Use Sprint",
+			input:   []any{map[string]any{"year": 2024}, map[string]any{"year": 1999}},
+			key:     "year",
+			pattern: "20??",
+			want:    []any{map[string]any{"year": 2024}},
+		},
+		{
+			name:    "No Match",
+			input:   invoices,
+			key:     "number",
+			pattern: "po-*",
+			want:    []any{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := WhereGlob(tt.input, tt.key, tt.pattern)
+			require.NoError(t, err)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("WhereGlob() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestWhereGlobErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := WhereGlob([]any{"a"}, "", "[a-")
+	require.ErrorIs(t, err, ErrFormat)
+
+	_, err = WhereGlob("not a slice", "", "*")
+	require.ErrorIs(t, err, ErrInvalidInput)
+}
+
+func TestWhereSimilar(t *testing.T) {
+	t.Parallel()
+
+	people := []any{
+		map[string]any{"name": "Jonathan"},
+		map[string]any{"name": "jonathon"},
+		map[string]any{"name": "Joanna"},
+		map[string]any{"name": "Zed"},
+		map[string]any{"nickname": "Jon"},
+	}
+
+	got, err := WhereSimilar(people, "name", "JONATHAN", 0.8)
+	require.NoError(t, err)
+	if diff := cmp.Diff([]any{people[0], people[1]}, got); diff != "" {
+		t.Fatalf("WhereSimilar() mismatch (-want +got):\n%s", diff)
+	}
+
+	got, err = WhereSimilar(people, "name", "jonathan", 0)
+	require.NoError(t, err)
+	require.Len(t, got, 4, "threshold 0 keeps every item with the key")
+
+	got, err = WhereSimilar([]string{"colour", "color", "collar"}, "", "color", 0.8)
+	require.NoError(t, err)
+	require.Equal(t, []any{"colour", "color"}, got)
+
+	for _, threshold := range []float64{-0.1, 1.5, math.NaN()} {
+		_, err = WhereSimilar(people, "name", "x", threshold)
+		require.ErrorIs(t, err, ErrInvalidInput, "threshold %v", threshold)
+	}
+}
+
 func TestWhereWithNonComparableValue(t *testing.T) {
 	t.Parallel()
 
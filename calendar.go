@@ -0,0 +1,252 @@
+package filter
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// maxNonBusinessRun bounds how many consecutive non-business days
+// AddBusinessDays will skip before giving up. It keeps a calendar whose
+// IsHoliday predicate rejects every day from looping forever.
+const maxNonBusinessRun = 3660
+
+// maxBusinessDays bounds the days AddBusinessDays accepts, about ten
+// thousand years of calendar days.
+const maxBusinessDays = 3_660_000
+
+// BusinessCalendar describes which calendar days count as business days.
+//
+// The zero value has no weekend and no holidays, so every day is a business
+// day. There is deliberately no package-level default calendar: callers pass
+// the weekend and holidays that apply to them on every call.
+//
+//	cal := filter.BusinessCalendar{
+//		Weekend:  []time.Weekday{time.Saturday, time.Sunday},
+//		Holidays: []time.Time{time.Date(2024, 12, 25, 0, 0, 0, 0, time.UTC)},
+//	}
+type BusinessCalendar struct {
+	// Weekend lists the weekdays that are never business days.
+	Weekend []time.Weekday
+	// Holidays lists non-business dates. Only the year, month, and day of
+	// each entry are compared, in the location of the date being tested.
+	Holidays []time.Time
+	// IsHoliday, when non-nil, reports additional non-business dates. It is
+	// consulted after Weekend and Holidays.
+	IsHoliday func(time.Time) bool
+}
+
+func (c BusinessCalendar) isBusinessDay(t time.Time) bool {
+	wd := t.Weekday()
+	for _, w := range c.Weekend {
+		if w == wd {
+			return false
+		}
+	}
+	y, m, d := t.Date()
+	for _, h := range c.Holidays {
+		hy, hm, hd := h.Date()
+		if hy == y && hm == m && hd == d {
+			return false
+		}
+	}
+	return c.IsHoliday == nil || !c.IsHoliday(t)
+}
+
+func (c BusinessCalendar) validate(op string) error {
+	var seen [7]bool
+	count := 0
+	for _, w := range c.Weekend {
+		if w < time.Sunday || w > time.Saturday {
+			return invalidInput(op, fmt.Errorf("invalid weekday %d", w))
+		}
+		if !seen[w] {
+			seen[w] = true
+			count++
+		}
+	}
+	if count == len(seen) {
+		return invalidInput(op, fmt.Errorf("weekend covers every weekday"))
+	}
+	return nil
+}
+
+// IsBusinessDay reports whether input falls on a business day of cal.
+// input accepts everything Date accepts.
+func IsBusinessDay(input any, cal BusinessCalendar) (bool, error) {
+	if err := cal.validate("IsBusinessDay"); err != nil {
+		return false, err
+	}
+	t, err := toTime(input)
+	if err != nil {
+		return false, err
+	}
+	return cal.isBusinessDay(t), nil
+}
+
+// AddBusinessDays moves input forward by days business days, or backward
+// when days is negative. Each step lands on the next business day, skipping
+// weekend days and holidays; the time of day and location are preserved.
+// Zero days returns input unchanged even when it is not a business day.
+//
+// Whole weeks are counted arithmetically, so the cost grows with the number
+// of holidays rather than with days, except that IsHoliday, when set, is
+// consulted for every weekday crossed.
+//
+// days beyond ±3,660,000, about ten thousand years, returns
+// *Error{Kind: KindInvalidInput}, as does a calendar whose weekend covers
+// every weekday or whose holidays leave no business day within ten years of
+// a step.
+func AddBusinessDays(input any, days int, cal BusinessCalendar) (time.Time, error) {
+	if err := cal.validate("AddBusinessDays"); err != nil {
+		return time.Time{}, err
+	}
+	// Checking the range before negating also rejects math.MinInt, whose
+	// negation overflows.
+	if days < -maxBusinessDays || days > maxBusinessDays {
+		return time.Time{}, invalidInput("AddBusinessDays", fmt.Errorf("days %d outside [-%d, %d]", days, maxBusinessDays, maxBusinessDays))
+	}
+	t, err := toTime(input)
+	if err != nil {
+		return time.Time{}, err
+	}
+	step := 1
+	if days < 0 {
+		step, days = -1, -days
+	}
+	calendar := newBusinessDays(cal, t)
+	pos, skipped := 0, 0
+	// Jump whole weeks while more than a week of business days remains. At
+	// least one day is always left for the loop below, so the result lands
+	// on a business day rather than at the end of a week.
+	for days > calendar.perWeek {
+		span := 7 * ((days - 1) / calendar.perWeek)
+		var n int
+		if step > 0 {
+			n = calendar.count(pos+1, pos+span+1)
+		} else {
+			n = calendar.count(pos-span, pos)
+		}
+		pos += step * span
+		days -= n
+		if n > 0 {
+			skipped = 0
+		} else if skipped += span; skipped > maxNonBusinessRun {
+			return time.Time{}, invalidInput("AddBusinessDays", fmt.Errorf("no business day within %d days", maxNonBusinessRun))
+		}
+	}
+	for days > 0 {
+		pos += step
+		if calendar.count(pos, pos+1) == 1 {
+			days--
+			skipped = 0
+			continue
+		}
+		if skipped++; skipped > maxNonBusinessRun {
+			return time.Time{}, invalidInput("AddBusinessDays", fmt.Errorf("no business day within %d days", maxNonBusinessRun))
+		}
+	}
+	return t.AddDate(0, 0, pos), nil
+}
+
+// BusinessDaysBetween counts the business days in the half-open range
+// [start, end): start is counted when it is a business day, end is not.
+// When end is before start the count is negated, so
+// BusinessDaysBetween(a, b) == -BusinessDaysBetween(b, a).
+//
+// Both dates are compared by calendar day in start's location; the time of
+// day is ignored. As in AddBusinessDays, whole weeks are counted
+// arithmetically.
+func BusinessDaysBetween(start, end any, cal BusinessCalendar) (int, error) {
+	if err := cal.validate("BusinessDaysBetween"); err != nil {
+		return 0, err
+	}
+	from, err := toTime(start)
+	if err != nil {
+		return 0, err
+	}
+	to, err := toTime(end)
+	if err != nil {
+		return 0, err
+	}
+	from = startOfDay(from)
+	n := civilDay(to.In(from.Location())) - civilDay(from)
+	if n < 0 {
+		return -newBusinessDays(cal, from).count(n, 0), nil
+	}
+	return newBusinessDays(cal, from).count(0, n), nil
+}
+
+// businessDays counts the business days of a calendar around base, naming
+// each day by its offset k from base, the day base.AddDate(0, 0, k).
+type businessDays struct {
+	cal     BusinessCalendar
+	base    time.Time
+	weekend [7]bool
+	perWeek int
+	// holidays holds the sorted offsets of the Holidays entries that fall
+	// on a day outside the weekend.
+	holidays []int
+}
+
+func newBusinessDays(cal BusinessCalendar, base time.Time) businessDays {
+	d := businessDays{cal: cal, base: base, perWeek: 7}
+	for _, w := range cal.Weekend {
+		if !d.weekend[w] {
+			d.weekend[w] = true
+			d.perWeek--
+		}
+	}
+	day := civilDay(base)
+	for _, h := range cal.Holidays {
+		if k := civilDay(h) - day; !d.isWeekend(k) {
+			d.holidays = append(d.holidays, k)
+		}
+	}
+	slices.Sort(d.holidays)
+	d.holidays = slices.Compact(d.holidays)
+	return d
+}
+
+func (d businessDays) isWeekend(k int) bool {
+	return d.weekend[(int(d.base.Weekday())+k%7+7)%7]
+}
+
+func (d businessDays) isListedHoliday(k int) bool {
+	_, found := slices.BinarySearch(d.holidays, k)
+	return found
+}
+
+// count returns the number of business days among the offsets [lo, hi).
+func (d businessDays) count(lo, hi int) int {
+	weeks := (hi - lo) / 7
+	n := weeks * d.perWeek
+	for k := lo + 7*weeks; k < hi; k++ {
+		if !d.isWeekend(k) {
+			n++
+		}
+	}
+	first, _ := slices.BinarySearch(d.holidays, lo)
+	last, _ := slices.BinarySearch(d.holidays, hi)
+	n -= last - first
+	if d.cal.IsHoliday != nil {
+		for k := lo; k < hi; k++ {
+			if !d.isWeekend(k) && !d.isListedHoliday(k) && d.cal.IsHoliday(d.base.AddDate(0, 0, k)) {
+				n--
+			}
+		}
+	}
+	return n
+}
+
+// civilDay numbers the calendar day of t, in t's location, counting from
+// January 1, 1970.
+func civilDay(t time.Time) int {
+	y, m, d := t.Date()
+	return int(time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Unix() / (24 * 60 * 60))
+}
+
+func startOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
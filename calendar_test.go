@@ -0,0 +1,301 @@
+package filter
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var satSun = []time.Weekday{time.Saturday, time.Sunday}
+
+func utcDate(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestIsBusinessDay(t *testing.T) {
+	t.Parallel()
+
+	cal := BusinessCalendar{
+		Weekend:  satSun,
+		Holidays: []time.Time{utcDate(2024, time.December, 25)},
+	}
+
+	tests := []struct {
+		name  string
+		input any
+		want  bool
+	}{
+		{"weekday", "2024-12-23", true},
+		{"saturday", "2024-12-21", false},
+		{"sunday", utcDate(2024, time.December, 22), false},
+		{"holiday ignores time of day", time.Date(2024, time.December, 25, 18, 30, 0, 0, time.UTC), false},
+		{"day after holiday", "2024-12-26", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := IsBusinessDay(tt.input, cal)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestIsBusinessDayZeroCalendar(t *testing.T) {
+	t.Parallel()
+
+	got, err := IsBusinessDay("2024-12-21", BusinessCalendar{})
+	require.NoError(t, err)
+	require.True(t, got)
+}
+
+func TestIsBusinessDayPredicate(t *testing.T) {
+	t.Parallel()
+
+	firstOfMonth := BusinessCalendar{IsHoliday: func(t time.Time) bool { return t.Day() == 1 }}
+
+	got, err := IsBusinessDay("2024-05-01", firstOfMonth)
+	require.NoError(t, err)
+	require.False(t, got)
+
+	got, err = IsBusinessDay("2024-05-02", firstOfMonth)
+	require.NoError(t, err)
+	require.True(t, got)
+}
+
+func TestAddBusinessDays(t *testing.T) {
+	t.Parallel()
+
+	cal := BusinessCalendar{
+		Weekend:  satSun,
+		Holidays: []time.Time{utcDate(2024, time.December, 25), utcDate(2024, time.December, 26)},
+	}
+
+	tests := []struct {
+		name  string
+		input any
+		days  int
+		want  time.Time
+	}{
+		{"zero days", "2024-12-21", 0, utcDate(2024, time.December, 21)},
+		{"within week", "2024-12-16", 3, utcDate(2024, time.December, 19)},
+		{"across weekend", "2024-12-20", 1, utcDate(2024, time.December, 23)},
+		{"from saturday", "2024-12-21", 1, utcDate(2024, time.December, 23)},
+		{"across holidays", "2024-12-24", 1, utcDate(2024, time.December, 27)},
+		{"backward across weekend", "2024-12-23", -1, utcDate(2024, time.December, 20)},
+		{"backward across holidays", "2024-12-27", -2, utcDate(2024, time.December, 23)},
+		{
+			"preserves time of day",
+			time.Date(2024, time.December, 20, 17, 45, 0, 0, time.UTC),
+			3,
+			time.Date(2024, time.December, 27, 17, 45, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := AddBusinessDays(tt.input, tt.days, cal)
+			require.NoError(t, err)
+			require.True(t, tt.want.Equal(got), "want %v, got %v", tt.want, got)
+		})
+	}
+}
+
+func TestAddBusinessDaysCustomWeekend(t *testing.T) {
+	t.Parallel()
+
+	cal := BusinessCalendar{Weekend: []time.Weekday{time.Friday, time.Saturday}}
+
+	got, err := AddBusinessDays("2024-12-19", 1, cal)
+	require.NoError(t, err)
+	require.Equal(t, utcDate(2024, time.December, 22), got)
+}
+
+func TestBusinessDaysBetween(t *testing.T) {
+	t.Parallel()
+
+	cal := BusinessCalendar{
+		Weekend:  satSun,
+		Holidays: []time.Time{utcDate(2024, time.December, 25)},
+	}
+
+	tests := []struct {
+		name       string
+		start, end any
+		want       int
+	}{
+		{"same day", "2024-12-23", "2024-12-23", 0},
+		{"one weekday", "2024-12-23", "2024-12-24", 1},
+		{"full week with holiday", "2024-12-23", "2024-12-30", 4},
+		{"weekend only", "2024-12-21", "2024-12-23", 0},
+		{"reversed", "2024-12-30", "2024-12-23", -4},
+		{
+			"ignores time of day",
+			time.Date(2024, time.December, 23, 23, 0, 0, 0, time.UTC),
+			time.Date(2024, time.December, 24, 1, 0, 0, 0, time.UTC),
+			1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := BusinessDaysBetween(tt.start, tt.end, cal)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestBusinessDaysRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	cal := BusinessCalendar{Weekend: satSun}
+	start := utcDate(2024, time.December, 2)
+	for days := range 20 {
+		end, err := AddBusinessDays(start, days, cal)
+		require.NoError(t, err)
+		got, err := BusinessDaysBetween(start, end, cal)
+		require.NoError(t, err)
+		require.Equal(t, days, got)
+	}
+}
+
+// stepBusinessDays is AddBusinessDays taken one calendar day at a time.
+func stepBusinessDays(t time.Time, days int, cal BusinessCalendar) time.Time {
+	step := 1
+	if days < 0 {
+		step, days = -1, -days
+	}
+	for range days {
+		t = t.AddDate(0, 0, step)
+		for !cal.isBusinessDay(t) {
+			t = t.AddDate(0, 0, step)
+		}
+	}
+	return t
+}
+
+func TestBusinessDaysMatchDayByDay(t *testing.T) {
+	t.Parallel()
+
+	holidays := []time.Time{
+		utcDate(2024, time.December, 25),
+		utcDate(2024, time.December, 26),
+		utcDate(2024, time.December, 28), // a Saturday
+		utcDate(2025, time.January, 1),
+		utcDate(2025, time.January, 1), // listed twice
+		utcDate(2025, time.January, 2),
+		utcDate(2025, time.January, 3),
+		utcDate(2025, time.January, 6),
+		utcDate(2025, time.January, 7),
+	}
+	calendars := map[string]BusinessCalendar{
+		"zero":           {},
+		"weekend":        {Weekend: satSun},
+		"holidays":       {Weekend: satSun, Holidays: holidays},
+		"single day off": {Weekend: []time.Weekday{time.Friday}, Holidays: holidays},
+		"predicate": {
+			Weekend:   satSun,
+			Holidays:  holidays,
+			IsHoliday: func(t time.Time) bool { return t.Day() == 13 },
+		},
+	}
+	for name, cal := range calendars {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			for _, start := range []time.Time{utcDate(2024, time.December, 20), utcDate(2025, time.January, 4)} {
+				for days := -40; days <= 40; days++ {
+					want := stepBusinessDays(start, days, cal)
+					got, err := AddBusinessDays(start, days, cal)
+					require.NoError(t, err)
+					require.True(t, want.Equal(got), "%d days from %v: want %v, got %v", days, start, want, got)
+
+					between, err := BusinessDaysBetween(start, start.AddDate(0, 0, days), cal)
+					require.NoError(t, err)
+					wantBetween := 0
+					for d := min(days, 0); d < max(days, 0); d++ {
+						if cal.isBusinessDay(start.AddDate(0, 0, d)) {
+							wantBetween++
+						}
+					}
+					if days < 0 {
+						wantBetween = -wantBetween
+					}
+					require.Equal(t, wantBetween, between, "between %v and %d days later", start, days)
+				}
+			}
+		})
+	}
+}
+
+func TestAddBusinessDaysLargeSpans(t *testing.T) {
+	t.Parallel()
+
+	cal := BusinessCalendar{Weekend: satSun, Holidays: []time.Time{utcDate(2024, time.December, 25)}}
+	start := utcDate(2024, time.December, 2)
+
+	// 52 weeks of 5 business days, plus one day for the holiday.
+	got, err := AddBusinessDays(start, 52*5, cal)
+	require.NoError(t, err)
+	require.Equal(t, utcDate(2025, time.December, 2), got)
+
+	got, err = AddBusinessDays(start, maxBusinessDays, cal)
+	require.NoError(t, err)
+	n, err := BusinessDaysBetween(start, got, cal)
+	require.NoError(t, err)
+	require.Equal(t, maxBusinessDays, n)
+
+	got, err = AddBusinessDays(start, -maxBusinessDays, cal)
+	require.NoError(t, err)
+	n, err = BusinessDaysBetween(start, got, cal)
+	require.NoError(t, err)
+	require.Equal(t, -maxBusinessDays, n)
+}
+
+func TestAddBusinessDaysRejectsOutOfRange(t *testing.T) {
+	t.Parallel()
+
+	for _, days := range []int{math.MinInt, math.MaxInt, maxBusinessDays + 1, -maxBusinessDays - 1} {
+		_, err := AddBusinessDays("2024-12-23", days, BusinessCalendar{Weekend: satSun})
+		require.ErrorIs(t, err, ErrInvalidInput, "days %d", days)
+	}
+}
+
+func TestBusinessCalendarRejectsInvalidCalendars(t *testing.T) {
+	t.Parallel()
+
+	allWeek := BusinessCalendar{Weekend: []time.Weekday{
+		time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday,
+	}}
+	_, err := IsBusinessDay("2024-12-23", allWeek)
+	require.ErrorIs(t, err, ErrInvalidInput)
+	_, err = AddBusinessDays("2024-12-23", 1, allWeek)
+	require.ErrorIs(t, err, ErrInvalidInput)
+	_, err = BusinessDaysBetween("2024-12-23", "2024-12-30", allWeek)
+	require.ErrorIs(t, err, ErrInvalidInput)
+
+	_, err = IsBusinessDay("2024-12-23", BusinessCalendar{Weekend: []time.Weekday{7}})
+	require.ErrorIs(t, err, ErrInvalidInput)
+
+	never := BusinessCalendar{IsHoliday: func(time.Time) bool { return true }}
+	_, err = AddBusinessDays("2024-12-23", 1, never)
+	require.ErrorIs(t, err, ErrInvalidInput)
+}
+
+func TestBusinessCalendarRejectsUnparseableDates(t *testing.T) {
+	t.Parallel()
+
+	_, err := AddBusinessDays("not-a-date", 1, BusinessCalendar{})
+	require.ErrorIs(t, err, ErrFormat)
+	_, err = BusinessDaysBetween("2024-12-23", struct{}{}, BusinessCalendar{})
+	require.ErrorIs(t, err, ErrInvalidInput)
+}
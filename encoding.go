@@ -0,0 +1,241 @@
+package filter
+
+import (
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime/quotedprintable"
+	"strings"
+
+	"golang.org/x/net/idna"
+)
+
+// HexEncode encodes a string as lower-case hexadecimal, two digits per
+// byte.
+func HexEncode(input string) string {
+	return hex.EncodeToString([]byte(input))
+}
+
+// HexDecode decodes a hexadecimal string. Upper- and lower-case digits are
+// accepted. An odd length or a non-hex character returns
+// *Error{Kind: KindFormat}.
+func HexDecode(input string) (string, error) {
+	b, err := hex.DecodeString(input)
+	if err != nil {
+		return "", formatErr("HexDecode", err)
+	}
+	return string(b), nil
+}
+
+// Base32Encode encodes a string with standard padded Base32 (RFC 4648 §6).
+func Base32Encode(input string) string {
+	return base32.StdEncoding.EncodeToString([]byte(input))
+}
+
+// Base32Decode decodes a standard padded Base32 string.
+func Base32Decode(input string) (string, error) {
+	b, err := base32.StdEncoding.DecodeString(input)
+	if err != nil {
+		return "", formatErr("Base32Decode", err)
+	}
+	return string(b), nil
+}
+
+// Base32HexEncode encodes a string with padded Base32 using the extended
+// hex alphabet 0-9A-V (RFC 4648 §7), which keeps the sort order of the
+// input bytes.
+func Base32HexEncode(input string) string {
+	return base32.HexEncoding.EncodeToString([]byte(input))
+}
+
+// Base32HexDecode decodes a padded Base32 string in the extended hex
+// alphabet.
+func Base32HexDecode(input string) (string, error) {
+	b, err := base32.HexEncoding.DecodeString(input)
+	if err != nil {
+		return "", formatErr("Base32HexDecode", err)
+	}
+	return string(b), nil
+}
+
+// Base64RawEncode encodes a string with standard Base64 without "="
+// padding.
+func Base64RawEncode(input string) string {
+	return base64.RawStdEncoding.EncodeToString([]byte(input))
+}
+
+// Base64RawDecode decodes a standard Base64 string without padding. Padded
+// input returns *Error{Kind: KindFormat}.
+func Base64RawDecode(input string) (string, error) {
+	b, err := base64.RawStdEncoding.DecodeString(input)
+	if err != nil {
+		return "", formatErr("Base64RawDecode", err)
+	}
+	return string(b), nil
+}
+
+// Base64RawURLEncode encodes a string with URL-safe Base64 without "="
+// padding, as used in JWTs.
+func Base64RawURLEncode(input string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(input))
+}
+
+// Base64RawURLDecode decodes a URL-safe Base64 string without padding.
+// Padded input returns *Error{Kind: KindFormat}.
+func Base64RawURLDecode(input string) (string, error) {
+	b, err := base64.RawURLEncoding.DecodeString(input)
+	if err != nil {
+		return "", formatErr("Base64RawURLDecode", err)
+	}
+	return string(b), nil
+}
+
+// base58Alphabet is the Bitcoin Base58 alphabet, which leaves out 0, O, I,
+// and l.
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// base58Index maps an alphabet character to its value, or -1.
+var base58Index = func() (index [256]int8) {
+	for i := range index {
+		index[i] = -1
+	}
+	for i := range len(base58Alphabet) {
+		index[base58Alphabet[i]] = int8(i)
+	}
+	return index
+}()
+
+// maxBase58Len bounds the input Base58Decode accepts. Base58 converts the
+// whole input as one number, which takes time quadratic in its length, so
+// unlike the other decoders it cannot take unbounded input; 8192 characters
+// decode in tens of milliseconds and cover the keys, hashes, and short
+// identifiers Base58 is used for.
+const maxBase58Len = 8192
+
+// Base58Encode encodes a string with Base58 using the Bitcoin alphabet.
+// Each leading zero byte becomes a leading "1". No checksum is added.
+//
+// The conversion takes time quadratic in the length of input, so encode
+// only short values; input of more than about 6 KB encodes to more than
+// the 8192 characters Base58Decode accepts.
+func Base58Encode(input string) string {
+	zeros := 0
+	for zeros < len(input) && input[zeros] == 0 {
+		zeros++
+	}
+	// Base58 digits, least significant first. log(256)/log(58) < 1.37.
+	digits := make([]byte, 0, (len(input)-zeros)*137/100+1)
+	for i := zeros; i < len(input); i++ {
+		carry := int(input[i])
+		for j := range digits {
+			carry += int(digits[j]) << 8
+			digits[j] = byte(carry % 58)
+			carry /= 58
+		}
+		for carry > 0 {
+			digits = append(digits, byte(carry%58))
+			carry /= 58
+		}
+	}
+
+	var b strings.Builder
+	b.Grow(zeros + len(digits))
+	b.WriteString(strings.Repeat("1", zeros))
+	for i := len(digits) - 1; i >= 0; i-- {
+		b.WriteByte(base58Alphabet[digits[i]])
+	}
+	return b.String()
+}
+
+// Base58Decode decodes a Base58 string in the Bitcoin alphabet. A character
+// outside the alphabet returns *Error{Kind: KindFormat}. Because decoding
+// takes time quadratic in the input length, input longer than 8192
+// characters returns *Error{Kind: KindInvalidInput}.
+func Base58Decode(input string) (string, error) {
+	if len(input) > maxBase58Len {
+		return "", invalidInput("Base58Decode", fmt.Errorf("input of %d bytes exceeds the %d byte limit", len(input), maxBase58Len))
+	}
+	zeros := 0
+	for zeros < len(input) && input[zeros] == '1' {
+		zeros++
+	}
+	// Bytes, least significant first. log(58)/log(256) < 0.74.
+	out := make([]byte, 0, (len(input)-zeros)*74/100+1)
+	for i := zeros; i < len(input); i++ {
+		v := base58Index[input[i]]
+		if v < 0 {
+			return "", formatErr("Base58Decode", fmt.Errorf("illegal base58 data at input byte %d", i))
+		}
+		carry := int(v)
+		for j := range out {
+			carry += int(out[j]) * 58
+			out[j] = byte(carry)
+			carry >>= 8
+		}
+		for carry > 0 {
+			out = append(out, byte(carry))
+			carry >>= 8
+		}
+	}
+
+	var b strings.Builder
+	b.Grow(zeros + len(out))
+	b.WriteString(strings.Repeat("\x00", zeros))
+	for i := len(out) - 1; i >= 0; i-- {
+		b.WriteByte(out[i])
+	}
+	return b.String(), nil
+}
+
+// QuotedPrintableEncode encodes a string as MIME quoted-printable text
+// (RFC 2045). Printable ASCII is kept, other bytes and "=" become "=XX"
+// escapes, lines are kept under 76 characters with soft line breaks, and
+// line breaks, whether "\n", "\r\n", or "\r", are written as "\r\n".
+func QuotedPrintableEncode(input string) string {
+	var b strings.Builder
+	w := quotedprintable.NewWriter(&b)
+	// Writing to a strings.Builder cannot fail.
+	_, _ = io.WriteString(w, input)
+	_ = w.Close()
+	return b.String()
+}
+
+// QuotedPrintableDecode decodes MIME quoted-printable text, removing soft
+// line breaks. Like most mail readers it is lenient: "=" not followed by
+// two hex digits is kept as is, and "=\n" is a soft line break too.
+// Unescaped control characters other than tab and line breaks, and
+// malformed soft line breaks, return *Error{Kind: KindFormat}.
+func QuotedPrintableDecode(input string) (string, error) {
+	b, err := io.ReadAll(quotedprintable.NewReader(strings.NewReader(input)))
+	if err != nil {
+		return "", formatErr("QuotedPrintableDecode", err)
+	}
+	return string(b), nil
+}
+
+// PunycodeEncode converts an internationalized domain name to its ASCII
+// form with IDNA (UTS #46), as done before a DNS lookup: labels are mapped,
+// such as to lower case, and each non-ASCII label is encoded with Punycode
+// behind an "xn--" prefix, so "Bücher.example" becomes "xn--bcher-kva.example".
+// A name that is not a valid domain name returns
+// *Error{Kind: KindInvalidInput}.
+func PunycodeEncode(input string) (string, error) {
+	out, err := idna.Lookup.ToASCII(input)
+	if err != nil {
+		return "", invalidInput("PunycodeEncode", err)
+	}
+	return out, nil
+}
+
+// PunycodeDecode converts an ASCII domain name with "xn--" labels back to
+// its Unicode form with IDNA (UTS #46). A malformed or invalid label
+// returns *Error{Kind: KindFormat}.
+func PunycodeDecode(input string) (string, error) {
+	out, err := idna.Lookup.ToUnicode(input)
+	if err != nil {
+		return "", formatErr("PunycodeDecode", err)
+	}
+	return out, nil
+}
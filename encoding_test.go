@@ -0,0 +1,276 @@
+package filter
+
+import (
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncoders(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		encode func(string) string
+		input  string
+		want   string
+	}{
+		{"Hex", HexEncode, "Hi!", "486921"},
+		{"Hex Empty", HexEncode, "", ""},
+		{"Hex Binary", HexEncode, "\x00\xff", "00ff"},
+		{"Base32", Base32Encode, "foobar", "MZXW6YTBOI======"},
+		{"Base32 Empty", Base32Encode, "", ""},
+		{"Base32Hex", Base32HexEncode, "foobar", "CPNMUOJ1E8======"},
+		{"Base64Raw", Base64RawEncode, "a", "YQ"},
+		{"Base64Raw Alphabet", Base64RawEncode, "\xfb\xff", "+/8"},
+		{"Base64RawURL", Base64RawURLEncode, "\xfb\xff", "-_8"},
+		{"Base64RawURL JSON", Base64RawURLEncode, `{"alg":"HS256"}`, "eyJhbGciOiJIUzI1NiJ9"},
+		{"Base58", Base58Encode, "Hello World!", "2NEpo7TZRRrLZSi2U"},
+		{"Base58 Empty", Base58Encode, "", ""},
+		{"Base58 Leading Zeros", Base58Encode, "\x00\x00abc", "11ZiCa"},
+		{"Base58 Only Zero", Base58Encode, "\x00", "1"},
+		{"QuotedPrintable ASCII", QuotedPrintableEncode, "hello", "hello"},
+		{"QuotedPrintable Escapes", QuotedPrintableEncode, "café = 1", "caf=C3=A9 =3D 1"},
+		{"QuotedPrintable Line Break", QuotedPrintableEncode, "a\nb", "a\r\nb"},
+		{"QuotedPrintable Trailing Space", QuotedPrintableEncode, "a \nb", "a=20\r\nb"},
+		{"QuotedPrintable Soft Break", QuotedPrintableEncode, strings.Repeat("x", 80), strings.Repeat("x", 75) + "=\r\nxxxxx"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tt.want, tt.encode(tt.input))
+		})
+	}
+}
+
+func TestDecoders(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		decode func(string) (string, error)
+		input  string
+		want   string
+	}{
+		{"Hex", HexDecode, "486921", "Hi!"},
+		{"Hex Upper Case", HexDecode, "4A4b", "JK"},
+		{"Hex Empty", HexDecode, "", ""},
+		{"Base32", Base32Decode, "MZXW6YTBOI======", "foobar"},
+		{"Base32Hex", Base32HexDecode, "CPNMUOJ1E8======", "foobar"},
+		{"Base64Raw", Base64RawDecode, "YQ", "a"},
+		{"Base64RawURL", Base64RawURLDecode, "-_8", "\xfb\xff"},
+		{"Base58", Base58Decode, "2NEpo7TZRRrLZSi2U", "Hello World!"},
+		{"Base58 Leading Ones", Base58Decode, "11ZiCa", "\x00\x00abc"},
+		{"Base58 Empty", Base58Decode, "", ""},
+		{"QuotedPrintable", QuotedPrintableDecode, "caf=C3=A9 =3D 1", "café = 1"},
+		{"QuotedPrintable Lower Case Hex", QuotedPrintableDecode, "caf=c3=a9", "café"},
+		{"QuotedPrintable Soft Break", QuotedPrintableDecode, "long=\r\nline", "longline"},
+		{"QuotedPrintable Literal Equals", QuotedPrintableDecode, "a=b", "a=b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tt.decode(tt.input)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDecodersInvalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		decode func(string) (string, error)
+		input  string
+	}{
+		{"Hex Odd Length", HexDecode, "abc"},
+		{"Hex Bad Digit", HexDecode, "zz"},
+		{"Base32 Bad Character", Base32Decode, "MZXW6YTB0I======"},
+		{"Base32 Missing Padding", Base32Decode, "MZXW6YTBOI"},
+		{"Base32Hex Std Alphabet", Base32HexDecode, "MZXW6YTBOI======"},
+		{"Base64Raw Padded", Base64RawDecode, "YQ=="},
+		{"Base64RawURL Std Alphabet", Base64RawURLDecode, "+/8"},
+		{"Base58 Zero", Base58Decode, "0abc"},
+		{"Base58 Lower L", Base58Decode, "abcl"},
+		{"Base58 Non-ASCII", Base58Decode, "ab€"},
+		{"QuotedPrintable Control Character", QuotedPrintableDecode, "a\x01b"},
+		{"Punycode Bad Label", PunycodeDecode, "xn--a.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := tt.decode(tt.input)
+			require.ErrorIs(t, err, ErrFormat)
+			var e *Error
+			require.ErrorAs(t, err, &e)
+			require.Error(t, e.Cause)
+		})
+	}
+}
+
+func TestDecodersPreserveCause(t *testing.T) {
+	t.Parallel()
+
+	_, err := HexDecode("abc")
+	require.ErrorIs(t, err, hex.ErrLength)
+
+	_, err = HexDecode("zz")
+	var invalidByte hex.InvalidByteError
+	require.ErrorAs(t, err, &invalidByte)
+
+	_, err = Base32Decode("MZXW6YTB0I======")
+	var base32Err base32.CorruptInputError
+	require.ErrorAs(t, err, &base32Err)
+	require.Equal(t, base32.CorruptInputError(8), base32Err)
+
+	_, err = Base64RawDecode("YQ==")
+	var base64Err base64.CorruptInputError
+	require.ErrorAs(t, err, &base64Err)
+
+	_, err = Base58Decode("12O")
+	require.ErrorContains(t, err, "input byte 2")
+}
+
+func TestBase58DecodeLengthLimit(t *testing.T) {
+	t.Parallel()
+
+	got, err := Base58Decode(strings.Repeat("z", maxBase58Len))
+	require.NoError(t, err)
+	require.Equal(t, strings.Repeat("z", maxBase58Len), Base58Encode(got))
+
+	_, err = Base58Decode(strings.Repeat("z", maxBase58Len+1))
+	require.ErrorIs(t, err, ErrInvalidInput)
+	_, err = Base58Decode(strings.Repeat("1", 1<<20))
+	require.ErrorIs(t, err, ErrInvalidInput)
+}
+
+func TestPunycode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		unicode string
+		ascii   string
+	}{
+		{"German", "bücher.example", "xn--bcher-kva.example"},
+		{"Japanese", "日本.jp", "xn--wgv71a.jp"},
+		{"ASCII Unchanged", "example.com", "example.com"},
+		{"Trailing Dot", "münchen.de.", "xn--mnchen-3ya.de."},
+		{"Empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := PunycodeEncode(tt.unicode)
+			require.NoError(t, err)
+			require.Equal(t, tt.ascii, got)
+
+			got, err = PunycodeDecode(tt.ascii)
+			require.NoError(t, err)
+			require.Equal(t, tt.unicode, got)
+		})
+	}
+}
+
+func TestPunycodeMapsCase(t *testing.T) {
+	t.Parallel()
+
+	got, err := PunycodeEncode("Bücher.EXAMPLE")
+	require.NoError(t, err)
+	require.Equal(t, "xn--bcher-kva.example", got)
+
+	got, err = PunycodeDecode("XN--BCHER-KVA.example")
+	require.NoError(t, err)
+	require.Equal(t, "bücher.example", got)
+}
+
+func TestPunycodeEncodeInvalid(t *testing.T) {
+	t.Parallel()
+
+	for _, input := range []string{"a b.com", "xn--a.com"} {
+		_, err := PunycodeEncode(input)
+		require.ErrorIs(t, err, ErrInvalidInput, "input %q", input)
+	}
+}
+
+func FuzzEncodingRoundTrip(f *testing.F) {
+	f.Add("Hello World!")
+	f.Add("\x00\x00\xff")
+	f.Add("café = 1")
+	f.Add("")
+	f.Fuzz(func(t *testing.T, input string) {
+		codecs := []struct {
+			name   string
+			encode func(string) string
+			decode func(string) (string, error)
+		}{
+			{"Hex", HexEncode, HexDecode},
+			{"Base32", Base32Encode, Base32Decode},
+			{"Base32Hex", Base32HexEncode, Base32HexDecode},
+			{"Base64Raw", Base64RawEncode, Base64RawDecode},
+			{"Base64RawURL", Base64RawURLEncode, Base64RawURLDecode},
+			{"Base58", Base58Encode, Base58Decode},
+			{"QuotedPrintable", QuotedPrintableEncode, QuotedPrintableDecode},
+		}
+		for _, c := range codecs {
+			// Quoted-printable writes every line break as \r\n.
+			if c.name == "QuotedPrintable" && strings.ContainsAny(input, "\r\n") {
+				continue
+			}
+			encoded := c.encode(input)
+			// Base58Decode refuses input beyond its length limit.
+			if c.name == "Base58" && len(encoded) > maxBase58Len {
+				continue
+			}
+			decoded, err := c.decode(encoded)
+			if err != nil {
+				t.Fatalf("%s: decoding %q from %q: %v", c.name, encoded, input, err)
+			}
+			if decoded != input {
+				t.Fatalf("%s: %q encodes to %q, which decodes to %q", c.name, input, encoded, decoded)
+			}
+		}
+	})
+}
+
+func FuzzPunycode(f *testing.F) {
+	f.Add("bücher.example")
+	f.Add("xn--bcher-kva.example")
+	f.Add("日本.jp.")
+	f.Fuzz(func(t *testing.T, input string) {
+		ascii, err := PunycodeEncode(input)
+		if err != nil {
+			if !errors.Is(err, ErrInvalidInput) {
+				t.Fatalf("PunycodeEncode(%q) error %v is not KindInvalidInput", input, err)
+			}
+			return
+		}
+		for i := range len(ascii) {
+			if ascii[i] >= 0x80 {
+				t.Fatalf("PunycodeEncode(%q) = %q is not ASCII", input, ascii)
+			}
+		}
+		decoded, err := PunycodeDecode(ascii)
+		if err != nil {
+			t.Fatalf("PunycodeDecode(%q) from %q: %v", ascii, input, err)
+		}
+		again, err := PunycodeEncode(decoded)
+		if err != nil || again != ascii {
+			t.Fatalf("PunycodeEncode(%q) = %q, %v; want %q", decoded, again, err, ascii)
+		}
+	})
+}
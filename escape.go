@@ -0,0 +1,178 @@
+package filter
+
+import (
+	"encoding/xml"
+	"net/url"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// EscapeJS escapes input for use inside a JavaScript string literal,
+// whether quoted with ', ", or `, including inside an HTML <script> element
+// or event handler attribute. The output is also valid inside a JSON
+// string.
+//
+// A backslash becomes \\; \n, \r, and \t keep their short escapes; the
+// quotes ' " and `, the characters < > & and $, other control characters,
+// and the line separators U+2028 and U+2029 become \uXXXX escapes with
+// upper-case hex digits. Invalid UTF-8 bytes become \uFFFD. Everything
+// else, including other non-ASCII text, is copied unchanged.
+func EscapeJS(input string) string {
+	var b strings.Builder
+	b.Grow(len(input))
+	for i := 0; i < len(input); {
+		r, size := utf8.DecodeRuneInString(input[i:])
+		i += size
+		switch {
+		case r == '\\':
+			b.WriteString(`\\`)
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\r':
+			b.WriteString(`\r`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case r < 0x20 || r == 0x7f || r == '\u2028' || r == '\u2029' || r == utf8.RuneError ||
+			strings.ContainsRune(`'"`+"`"+`<>&$`, r):
+			writeUnicodeEscape(&b, r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func writeUnicodeEscape(b *strings.Builder, r rune) {
+	const hex = "0123456789ABCDEF"
+	b.WriteString(`\u`)
+	for shift := 12; shift >= 0; shift -= 4 {
+		b.WriteByte(hex[(r>>shift)&0xf])
+	}
+}
+
+// EscapeCSS escapes input for use as a CSS identifier or inside a quoted
+// CSS string, following the CSS.escape algorithm of the CSSOM
+// specification. ASCII letters, digits, "-", "_", and non-ASCII characters
+// are kept; other printable ASCII characters are preceded by a backslash;
+// control characters become a hex escape such as "\a " and NUL becomes
+// "\fffd ". A leading digit, or a digit after a leading "-", is also hex
+// escaped so the result is a valid identifier, and a lone "-" becomes "\-".
+// Invalid UTF-8 bytes become U+FFFD.
+func EscapeCSS(input string) string {
+	var b strings.Builder
+	b.Grow(len(input))
+	runes := []rune(input)
+	for i, r := range runes {
+		switch {
+		case r == 0:
+			b.WriteString(`\fffd `)
+		case r < 0x20 || r == 0x7f,
+			i == 0 && '0' <= r && r <= '9',
+			i == 1 && '0' <= r && r <= '9' && runes[0] == '-':
+			b.WriteByte('\\')
+			b.WriteString(strconv.FormatInt(int64(r), 16))
+			b.WriteByte(' ')
+		case i == 0 && r == '-' && len(runes) == 1:
+			b.WriteString(`\-`)
+		case r >= 0x80 || r == '-' || r == '_' || isASCIIAlnum(r):
+			b.WriteRune(r)
+		default:
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// EscapeXML escapes input for use in XML 1.0 text or a quoted attribute
+// value, exactly as encoding/xml.EscapeText does: & < > " and ' become
+// &amp; &lt; &gt; &#34; and &#39;; tab, newline, and carriage return become
+// &#x9; &#xA; and &#xD; so attribute values keep them; and characters XML
+// does not allow, including invalid UTF-8, become U+FFFD.
+func EscapeXML(input string) string {
+	var b strings.Builder
+	// Writing to a strings.Builder cannot fail.
+	_ = xml.EscapeText(&b, []byte(input))
+	return b.String()
+}
+
+// ShellQuote quotes input as a single word for a POSIX shell such as sh,
+// bash, or zsh. Input made only of ASCII letters, digits, and the
+// characters @ % + = : , . / - _ is returned unchanged. Anything else is
+// wrapped in single quotes; each single quote inside it ends the quoting,
+// is written as \', and starts it again, so the shell reads everything
+// literally. The empty string becomes a pair of single quotes. It does not
+// quote for Windows cmd.exe or PowerShell.
+func ShellQuote(input string) string {
+	if input == "" {
+		return "''"
+	}
+	if strings.IndexFunc(input, func(r rune) bool { return !isShellSafe(r) }) < 0 {
+		return input
+	}
+	return "'" + strings.ReplaceAll(input, "'", `'\''`) + "'"
+}
+
+func isShellSafe(r rune) bool {
+	return isASCIIAlnum(r) || strings.ContainsRune("@%+=:,./-_", r)
+}
+
+// CSVEscape formats input as one field of a comma-separated CSV record as
+// defined by RFC 4180. A field that contains a comma, double quote, line
+// break, or leading or trailing whitespace is wrapped in double quotes,
+// with each " doubled.
+//
+// To guard against formula injection in spreadsheet applications, a field
+// starting with =, +, -, @, tab, or carriage return is prefixed with a
+// single quote so it is shown as text. Fields that are plain decimal
+// numbers, such as "-42" or "+1.5", are left as they are.
+func CSVEscape(input string) string {
+	if input != "" && strings.ContainsRune("=+-@\t\r", rune(input[0])) && !isSignedNumber(input) {
+		input = "'" + input
+	}
+	if input == "" || !csvNeedsQuotes(input) {
+		return input
+	}
+	return `"` + strings.ReplaceAll(input, `"`, `""`) + `"`
+}
+
+func csvNeedsQuotes(s string) bool {
+	if strings.ContainsAny(s, ",\"\r\n") {
+		return true
+	}
+	first, _ := utf8.DecodeRuneInString(s)
+	last, _ := utf8.DecodeLastRuneInString(s)
+	return unicode.IsSpace(first) || unicode.IsSpace(last)
+}
+
+// isSignedNumber reports whether s is a plain decimal number with a
+// leading sign, digits, and an optional fraction.
+func isSignedNumber(s string) bool {
+	if s[0] != '+' && s[0] != '-' {
+		return false
+	}
+	s = s[1:]
+	digits, dot := 0, false
+	for i := range len(s) {
+		switch c := s[i]; {
+		case '0' <= c && c <= '9':
+			digits++
+		case c == '.' && !dot:
+			dot = true
+		default:
+			return false
+		}
+	}
+	return digits > 0
+}
+
+// URLPathEscape escapes input for use as one segment of a URL path, as
+// url.PathEscape does: ASCII letters, digits, and - . _ ~ $ & + = : @ are
+// kept and every other byte, including "/", "?", "#", ";", ",", and space,
+// is percent-encoded. A segment of "." or ".." is left unchanged,
+// so callers building paths from untrusted input must reject those.
+func URLPathEscape(input string) string {
+	return url.PathEscape(input)
+}
@@ -0,0 +1,402 @@
+package filter
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"net/url"
+	"os/exec"
+	"strconv"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEscapeJS(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"Empty", "", ""},
+		{"Plain", "hello world", "hello world"},
+		{"Quotes", `'a' "b" ` + "`c`", `\u0027a\u0027 \u0022b\u0022 \u0060c\u0060`},
+		{"Backslash", `a\b`, `a\\b`},
+		{"Short Escapes", "a\nb\rc\td", `a\nb\rc\td`},
+		{"Script Close Tag", "</script><b>", `\u003C/script\u003E\u003Cb\u003E`},
+		{"Ampersand", "a&b", `a\u0026b`},
+		{"Template Substitution", "${x}", `\u0024{x}`},
+		{"Control Characters", "\x00\x1f\x7f", `\u0000\u001F\u007F`},
+		{"Line Separators", "a\u2028b\u2029c", `a\u2028b\u2029c`},
+		{"Non-ASCII Unchanged", "café 日本 😀", "café 日本 😀"},
+		{"Invalid UTF-8", "a\xffb", `a\uFFFDb`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tt.want, EscapeJS(tt.input))
+		})
+	}
+}
+
+func FuzzEscapeJS(f *testing.F) {
+	f.Add("</script>'\"`${x}\\")
+	f.Add("a b\x00\n")
+	f.Add("\xff日本")
+	f.Fuzz(func(t *testing.T, input string) {
+		got := EscapeJS(input)
+		if strings.ContainsAny(got, "'\"`<>&$\n\r\u2028\u2029") {
+			t.Fatalf("EscapeJS(%q) = %q contains an unescaped character", input, got)
+		}
+		var decoded string
+		if err := json.Unmarshal([]byte(`"`+got+`"`), &decoded); err != nil {
+			t.Fatalf("EscapeJS(%q) = %q is not a valid string body: %v", input, got, err)
+		}
+		if want := string([]rune(input)); decoded != want {
+			t.Fatalf("EscapeJS(%q) decodes to %q, want %q", input, decoded, want)
+		}
+	})
+}
+
+func TestEscapeCSS(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"Empty", "", ""},
+		{"Identifier", "main-nav_item2", "main-nav_item2"},
+		{"Punctuation", "a.b#c:d", `a\.b\#c\:d`},
+		{"Space", "a b", `a\ b`},
+		{"Quotes And Backslash", `"'\`, `\"\'\\`},
+		{"Closing Brace", "}", `\}`},
+		{"Leading Digit", "1st", `\31 st`},
+		{"Digit After Leading Dash", "-1x", `-\31 x`},
+		{"Digit Not At Start", "a1", "a1"},
+		{"Lone Dash", "-", `\-`},
+		{"Double Dash", "--x", "--x"},
+		{"Control Characters", "a\nb\x7f", `a\a b\7f `},
+		{"NUL", "a\x00b", `a\fffd b`},
+		{"Non-ASCII Unchanged", "café😀", "café😀"},
+		{"Invalid UTF-8", "a\xffb", "a\uFFFDb"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tt.want, EscapeCSS(tt.input))
+		})
+	}
+}
+
+func FuzzEscapeCSS(f *testing.F) {
+	f.Add("1st.class#id")
+	f.Add("-")
+	f.Add("-2\x00\n\xff")
+	f.Fuzz(func(t *testing.T, input string) {
+		got := EscapeCSS(input)
+		want := strings.ReplaceAll(string([]rune(input)), "\x00", "\uFFFD")
+		if decoded := unescapeCSS(got); decoded != want {
+			t.Fatalf("EscapeCSS(%q) = %q decodes to %q, want %q", input, got, decoded, want)
+		}
+		if strings.ContainsAny(got, "\x00\n\r\f") {
+			t.Fatalf("EscapeCSS(%q) = %q contains a raw control character", input, got)
+		}
+	})
+}
+
+// unescapeCSS reverses CSS escapes: a backslash followed by one to six hex
+// digits and an optional space, or by any other character.
+func unescapeCSS(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		if s[i] != '\\' || i+1 == len(s) {
+			r, size := utf8.DecodeRuneInString(s[i:])
+			b.WriteRune(r)
+			i += size
+			continue
+		}
+		i++
+		j := i
+		for j < len(s) && j-i < 6 && strings.IndexByte("0123456789abcdefABCDEF", s[j]) >= 0 {
+			j++
+		}
+		if j == i {
+			r, size := utf8.DecodeRuneInString(s[i:])
+			b.WriteRune(r)
+			i += size
+			continue
+		}
+		n, _ := strconv.ParseInt(s[i:j], 16, 32)
+		b.WriteRune(rune(n))
+		i = j
+		if i < len(s) && s[i] == ' ' {
+			i++
+		}
+	}
+	return b.String()
+}
+
+func TestEscapeXML(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"Empty", "", ""},
+		{"Plain", "hello", "hello"},
+		{"Markup", `<a href="x">Tom & 'Jerry'</a>`, "&lt;a href=&#34;x&#34;&gt;Tom &amp; &#39;Jerry&#39;&lt;/a&gt;"},
+		{"Whitespace Kept In Attributes", "a\tb\nc\rd", "a&#x9;b&#xA;c&#xD;d"},
+		{"Disallowed Control Character", "a\x01b", "a\uFFFDb"},
+		{"Invalid UTF-8", "a\xffb", "a\uFFFDb"},
+		{"Non-ASCII Unchanged", "café 日本", "café 日本"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tt.want, EscapeXML(tt.input))
+		})
+	}
+}
+
+func FuzzEscapeXML(f *testing.F) {
+	f.Add(`<a b="c">&'</a>`)
+	f.Add("\t\r\n\x01\xff")
+	f.Add("]]>")
+	f.Fuzz(func(t *testing.T, input string) {
+		got := EscapeXML(input)
+		var doc struct {
+			Attr string `xml:"a,attr"`
+			Text string `xml:",chardata"`
+		}
+		if err := xml.Unmarshal([]byte(`<x a="`+got+`">`+got+`</x>`), &doc); err != nil {
+			t.Fatalf("EscapeXML(%q) = %q does not parse: %v", input, got, err)
+		}
+		want := strings.Map(func(r rune) rune {
+			if r == utf8.RuneError || !isXMLChar(r) {
+				return '\uFFFD'
+			}
+			return r
+		}, input)
+		if doc.Attr != want || doc.Text != want {
+			t.Fatalf("EscapeXML(%q) decodes to %q and %q, want %q", input, doc.Attr, doc.Text, want)
+		}
+	})
+}
+
+func isXMLChar(r rune) bool {
+	return r == '\t' || r == '\n' || r == '\r' ||
+		r >= 0x20 && r <= 0xD7FF || r >= 0xE000 && r <= 0xFFFD || r >= 0x10000 && r <= 0x10FFFF
+}
+
+func TestShellQuote(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"Empty", "", "''"},
+		{"Safe Word", "file-1.txt", "file-1.txt"},
+		{"Safe Punctuation", "user@host:/a/b,c=d+e%f", "user@host:/a/b,c=d+e%f"},
+		{"Space", "hello world", "'hello world'"},
+		{"Single Quote", "it's", `'it'\''s'`},
+		{"Only Quote", "'", `''\'''`},
+		{"Expansions", "$HOME `id` $(id) *", "'$HOME `id` $(id) *'"},
+		{"Separators", "a;b|c&d", "'a;b|c&d'"},
+		{"Newline", "a\nb", "'a\nb'"},
+		{"Leading Dash Safe", "-rf", "-rf"},
+		{"Non-ASCII Quoted", "café", "'café'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tt.want, ShellQuote(tt.input))
+		})
+	}
+}
+
+func TestShellQuoteWithShell(t *testing.T) {
+	t.Parallel()
+
+	sh, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skip("sh not available")
+	}
+	for _, input := range []string{"", "hello world", "it's", "$HOME `id` $(id)", "a\nb\\c", "*?[a]", "'\"'", "~user"} {
+		out, err := exec.Command(sh, "-c", "printf %s "+ShellQuote(input)).Output()
+		require.NoError(t, err, "input %q", input)
+		require.Equal(t, input, string(out))
+	}
+}
+
+func FuzzShellQuote(f *testing.F) {
+	f.Add("it's $HOME")
+	f.Add("")
+	f.Add("'''")
+	f.Fuzz(func(t *testing.T, input string) {
+		got := ShellQuote(input)
+		decoded, ok := unquoteShell(got)
+		if !ok {
+			t.Fatalf("ShellQuote(%q) = %q is not one shell word", input, got)
+		}
+		if decoded != input {
+			t.Fatalf("ShellQuote(%q) = %q decodes to %q", input, got, decoded)
+		}
+	})
+}
+
+// unquoteShell parses s as one POSIX shell word made of single-quoted
+// strings, backslash-escaped characters, and characters that need no
+// quoting. It reports false for anything else.
+func unquoteShell(s string) (string, bool) {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '\'':
+			end := strings.IndexByte(s[i+1:], '\'')
+			if end < 0 {
+				return "", false
+			}
+			b.WriteString(s[i+1 : i+1+end])
+			i += end + 1
+		case c == '\\' && i+1 < len(s):
+			i++
+			b.WriteByte(s[i])
+		case isShellSafe(rune(c)):
+			b.WriteByte(c)
+		default:
+			return "", false
+		}
+	}
+	return b.String(), s != ""
+}
+
+func TestCSVEscape(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"Empty", "", ""},
+		{"Plain", "hello", "hello"},
+		{"Comma", "a,b", `"a,b"`},
+		{"Quote", `say "hi"`, `"say ""hi"""`},
+		{"Newline", "a\nb", "\"a\nb\""},
+		{"Leading Space", " a", `" a"`},
+		{"Trailing Space", "a ", `"a "`},
+		{"Inner Space", "a b", "a b"},
+		{"Formula", "=SUM(A1:A2)", "'=SUM(A1:A2)"},
+		{"Formula With Comma", `=HYPERLINK("http://x",1)`, `"'=HYPERLINK(""http://x"",1)"`},
+		{"Plus", "+cmd", "'+cmd"},
+		{"Minus", "-cmd", "'-cmd"},
+		{"At", "@SUM(1)", "'@SUM(1)"},
+		{"Tab", "\t=1", "'\t=1"},
+		{"Carriage Return", "\r=1", "\"'\r=1\""},
+		{"Negative Number", "-42", "-42"},
+		{"Signed Decimal", "+1.5", "+1.5"},
+		{"Lone Sign", "-", "'-"},
+		{"Two Dots", "-1.2.3", "'-1.2.3"},
+		{"Equals Number", "=5", "'=5"},
+		{"Formula Later", "a=1", "a=1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tt.want, CSVEscape(tt.input))
+		})
+	}
+}
+
+func FuzzCSVEscape(f *testing.F) {
+	f.Add("=1+1")
+	f.Add(`a,"b"`)
+	f.Add("-3.5")
+	f.Add(" x\ny ")
+	f.Fuzz(func(t *testing.T, input string) {
+		// csv.Reader turns \r\n inside quoted fields into \n.
+		if input == "" || strings.Contains(input, "\r") {
+			return
+		}
+		got := CSVEscape(input)
+		if c := got[0]; c == '"' && len(got) > 1 {
+			c = got[1]
+			if strings.IndexByte("=+-@\t", c) >= 0 {
+				t.Fatalf("CSVEscape(%q) = %q starts with a formula character", input, got)
+			}
+		} else if strings.IndexByte("=+-@\t", c) >= 0 && !isSignedNumber(got) {
+			t.Fatalf("CSVEscape(%q) = %q starts with a formula character", input, got)
+		}
+		want := input
+		if strings.IndexByte("=+-@\t", input[0]) >= 0 && !isSignedNumber(input) {
+			want = "'" + input
+		}
+		r := csv.NewReader(strings.NewReader(got + "\n"))
+		record, err := r.Read()
+		if err != nil {
+			t.Fatalf("CSVEscape(%q) = %q does not parse: %v", input, got, err)
+		}
+		if len(record) != 1 || record[0] != want {
+			t.Fatalf("CSVEscape(%q) = %q parses to %q, want [%q]", input, got, record, want)
+		}
+	})
+}
+
+func TestURLPathEscape(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"Empty", "", ""},
+		{"Plain", "report-2024_v1.0~draft", "report-2024_v1.0~draft"},
+		{"Space", "my file", "my%20file"},
+		{"Slash", "a/b", "a%2Fb"},
+		{"Query And Fragment", "a?b#c", "a%3Fb%23c"},
+		{"Kept Sub-Delimiters", "a$b&c+d=e:f@g", "a$b&c+d=e:f@g"},
+		{"Escaped Sub-Delimiters", "a;b,c", "a%3Bb%2Cc"},
+		{"Percent", "100%", "100%25"},
+		{"Non-ASCII", "café", "caf%C3%A9"},
+		{"Dot Segment Unchanged", "..", ".."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tt.want, URLPathEscape(tt.input))
+		})
+	}
+}
+
+func FuzzURLPathEscape(f *testing.F) {
+	f.Add("a/b?c#d")
+	f.Add("100% café")
+	f.Add("..")
+	f.Fuzz(func(t *testing.T, input string) {
+		got := URLPathEscape(input)
+		if strings.ContainsAny(got, "/?# ") {
+			t.Fatalf("URLPathEscape(%q) = %q contains a path delimiter", input, got)
+		}
+		decoded, err := url.PathUnescape(got)
+		if err != nil || decoded != input {
+			t.Fatalf("URLPathEscape(%q) = %q unescapes to %q, %v", input, got, decoded, err)
+		}
+	})
+}
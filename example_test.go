@@ -2,6 +2,7 @@ package filter_test
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/kaptinlin/filter"
 )
@@ -12,6 +13,14 @@ func ExampleTrim() {
 	// Output: hello
 }
 
+func ExampleTitleCase() {
+	fmt.Println(filter.TitleCase("the lord of the rings", filter.TitleCaseAP))
+	fmt.Println(filter.TitleCase("a walk through the woods", filter.TitleCaseChicago))
+	// Output:
+	// The Lord of the Rings
+	// A Walk through the Woods
+}
+
 func ExampleCamelize() {
 	fmt.Println(filter.Camelize("hello_world"))
 	fmt.Println(filter.Camelize("foo-bar-baz"))
@@ -36,12 +45,41 @@ func ExampleDasherize() {
 	// foo-bar
 }
 
+func ExampleSnakeCase() {
+	fmt.Println(filter.SnakeCase("helloWorld"))
+	fmt.Println(filter.ConstantCase("helloWorld"))
+	fmt.Println(filter.TrainCase("user_id"))
+	// Output:
+	// hello_world
+	// HELLO_WORLD
+	// User-ID
+}
+
+func ExampleCamelizeWithOptions() {
+	opts := filter.CaseOptions{Acronyms: []string{"GraphQL", "iOS"}}
+	fmt.Println(filter.CamelizeWithOptions("myGraphqlSchema", opts))
+	fmt.Println(filter.PascalizeWithOptions("ios_app", opts))
+	// Output:
+	// myGraphQLSchema
+	// iOSApp
+}
+
 func ExampleSlugify() {
 	result := filter.Slugify("Hello World!")
 	fmt.Println(result)
 	// Output: hello-world
 }
 
+func ExampleSlugifyWithOptions() {
+	result, _ := filter.SlugifyWithOptions("Müller & Söhne GmbH", filter.SlugOptions{
+		Language:  "de",
+		Separator: "_",
+		MaxLength: 20,
+	})
+	fmt.Println(result)
+	// Output: mueller_und_soehne
+}
+
 func ExampleTruncate() {
 	fmt.Println(filter.Truncate("Hello, World!", 8))
 	fmt.Println(filter.Truncate("Hi", 5))
@@ -52,6 +90,97 @@ func ExampleTruncate() {
 	// Hello, W--
 }
 
+func ExampleWordCount() {
+	fmt.Println(filter.WordCount("Don't panic: it's a well-known trick."))
+	// Output: 6
+}
+
+func ExampleSentenceSplit() {
+	for _, sentence := range filter.SentenceSplit("Hello there. Pi is 3.14, e.g. roughly. Really?! Yes.") {
+		fmt.Println(sentence)
+	}
+	// Output:
+	// Hello there.
+	// Pi is 3.14, e.g. roughly.
+	// Really?!
+	// Yes.
+}
+
+func ExampleReadingTime() {
+	post := strings.Repeat("word ", 1150)
+	fmt.Printf("%d min read\n", filter.ReadingTime(post, 0))
+	fmt.Printf("%d min read\n", filter.ReadingTime(post, 250))
+	// Output:
+	// 6 min read
+	// 5 min read
+}
+
+func ExampleExcerpt() {
+	text := "The quick brown fox jumps over the lazy dog while the farmer sleeps."
+	fmt.Println(filter.Excerpt(text, "lazy", 5))
+	fmt.Println(filter.ExcerptWithOptions("Order the Crème Brûlée for dessert", "creme brulee", 4, filter.ExcerptOptions{Fold: true}, "…"))
+	// Output:
+	// ...over the lazy dog while...
+	// …the Crème Brûlée for…
+}
+
+func ExampleHighlight() {
+	fmt.Println(filter.Highlight("Tom & Jerry", "Jerry", "<mark>", "</mark>"))
+	fmt.Println(filter.HighlightWithOptions("Café <b>cafe</b>", "CAFE", "<mark>", "</mark>", filter.HighlightOptions{Fold: true}))
+	// Output:
+	// Tom &amp; <mark>Jerry</mark>
+	// <mark>Café</mark> &lt;b&gt;<mark>cafe</mark>&lt;/b&gt;
+}
+
+func ExampleWrap() {
+	fmt.Println(filter.Wrap("the quick brown fox jumps over the lazy dog", 15))
+	// Output:
+	// the quick brown
+	// fox jumps over
+	// the lazy dog
+}
+
+func ExampleWrapWithOptions() {
+	fmt.Println(filter.WrapWithOptions("the quick brown fox\n\njumps over", 12, filter.WrapOptions{Prefix: "> "}))
+	// Output:
+	// > the quick
+	// > brown fox
+	// >
+	// > jumps over
+}
+
+func ExampleIndent() {
+	fmt.Println(filter.Indent("key: |\nline one\nline two", "  ", true))
+	// Output:
+	// key: |
+	//   line one
+	//   line two
+}
+
+func ExampleDedent() {
+	fmt.Println(filter.Dedent("    if x {\n        y()\n    }"))
+	// Output:
+	// if x {
+	//     y()
+	// }
+}
+
+func ExampleRegexReplace() {
+	result, _ := filter.RegexReplace("2024-03-31", `(?P<y>\d+)-(?P<m>\d+)-(?P<d>\d+)`, "${d}/${m}/${y}")
+	fmt.Println(result)
+	// Output: 31/03/2024
+}
+
+func ExampleUnaccent() {
+	fmt.Println(filter.Unaccent("Crème Brûlée à Łódź"))
+	// Output: Creme Brulee a Lodz
+}
+
+func ExampleCaseFold() {
+	fmt.Println(filter.CaseFold("Straße") == filter.CaseFold("STRASSE"))
+	// Output: true
+}
+
 func ExamplePluralize() {
 	fmt.Println(filter.Pluralize(1, "item", "items"))
 	fmt.Println(filter.Pluralize(5, "item", "items"))
@@ -84,6 +213,61 @@ func ExampleExtract() {
 	// Output: Alice
 }
 
+func ExampleToJSON() {
+	data := map[string]any{"name": "Ada", "tags": []string{"math", "code"}, "admin": false}
+	result, _ := filter.ToJSON(data)
+	fmt.Println(result)
+	// Output: {"admin":false,"name":"Ada","tags":["math","code"]}
+}
+
+func ExampleParseJSON() {
+	value, _ := filter.ParseJSON(`{"id": 12345678901234567890, "tags": ["a", "b"]}`)
+	id, _ := filter.Extract(value, "id")
+	fmt.Println(id)
+
+	_, err := filter.ParseJSON(`{"a": 1,}`)
+	fmt.Println(err)
+	// Output:
+	// 12345678901234567890
+	// ParseJSON: format: at byte offset 9: invalid character '}' looking for beginning of object key string
+}
+
+func ExampleToYAML() {
+	config := map[string]any{"name": "app", "db": map[string]any{"host": "localhost", "port": 5432}}
+	result, _ := filter.ToYAML(config)
+	fmt.Print(result)
+	// Output:
+	// db:
+	//   host: localhost
+	//   port: 5432
+	// name: app
+}
+
+func ExampleToTOML() {
+	config := map[string]any{"title": "app", "db": map[string]any{"host": "localhost", "port": 5432}}
+	result, _ := filter.ToTOML(config)
+	fmt.Print(result)
+	// Output:
+	// title = "app"
+	//
+	// [db]
+	// host = "localhost"
+	// port = 5432
+}
+
+func ExampleToCSV() {
+	users := []map[string]any{
+		{"name": "Ada", "address": map[string]any{"city": "London, UK"}},
+		{"name": "=cmd()", "address": map[string]any{"city": "Paris"}},
+	}
+	result, _ := filter.ToCSV(users, []string{"name", "address.city"})
+	fmt.Print(result)
+	// Output:
+	// name,address.city
+	// Ada,"London, UK"
+	// '=cmd(),Paris
+}
+
 func ExampleUnique() {
 	result, _ := filter.Unique([]any{1, 2, 2, 3, 3, 3})
 	fmt.Println(result)
@@ -121,11 +305,66 @@ func ExampleEscapeOnce() {
 	// 1 &lt; 2 &amp; 3
 }
 
+func ExampleEscapeJS() {
+	fmt.Println(filter.EscapeJS(`</script>"Hi"`))
+	// Output: \u003C/script\u003E\u0022Hi\u0022
+}
+
+func ExampleShellQuote() {
+	fmt.Println(filter.ShellQuote("report.txt"))
+	fmt.Println(filter.ShellQuote("it's $HOME"))
+	// Output:
+	// report.txt
+	// 'it'\''s $HOME'
+}
+
+func ExampleCSVEscape() {
+	fmt.Println(filter.CSVEscape(`say "hi", world`))
+	fmt.Println(filter.CSVEscape("=SUM(A1:A9)"))
+	fmt.Println(filter.CSVEscape("-42"))
+	// Output:
+	// "say ""hi"", world"
+	// '=SUM(A1:A9)
+	// -42
+}
+
 func ExampleStripHTML() {
 	fmt.Println(filter.StripHTML("<p>Hello <b>World</b></p>"))
 	// Output: Hello World
 }
 
+func ExampleHTMLToText() {
+	text := filter.HTMLToText(`<p>Hi &amp; welcome!</p><ul><li>Read the <a href="https://example.com/docs">docs</a></li><li>Say hello</li></ul>`)
+	fmt.Println(text)
+	// Output:
+	// Hi & welcome!
+	//
+	// - Read the docs (https://example.com/docs)
+	// - Say hello
+}
+
+func ExampleSanitizeHTML() {
+	input := `<p onclick="steal()">Hi <a href="javascript:alert(1)">there</a> <a href="https://example.com">friend</a><script>alert(1)</script></p>`
+	fmt.Println(filter.SanitizeHTML(input, filter.UGCHTMLPolicy()))
+	fmt.Println(filter.SanitizeHTML(input, filter.StrictHTMLPolicy()))
+	// Output:
+	// <p>Hi <a>there</a> <a href="https://example.com" rel="nofollow">friend</a></p>
+	// Hi there friend
+}
+
+func ExampleMarkdown() {
+	fmt.Print(filter.Markdown("# Hello\n\nSome *emphasis* and <b>tags</b>."))
+	// Output:
+	// <h1>Hello</h1>
+	// <p>Some <em>emphasis</em> and &lt;b&gt;tags&lt;/b&gt;.</p>
+}
+
+func ExampleMarkdownToText() {
+	text := filter.MarkdownToText("This release adds **Markdown** support and [new docs](https://example.com).")
+	fmt.Println(filter.TruncateWords(text, 4))
+	// Output: This release adds Markdown...
+}
+
 func ExampleTrimLeft() {
 	fmt.Println(filter.TrimLeft("  hello  "))
 	// Output: hello
@@ -151,6 +390,32 @@ func ExampleURLEncode() {
 	// Output: hello+world
 }
 
+func ExampleParseURL() {
+	parts, _ := filter.ParseURL("https://example.com:8080/docs?page=2&tag=a&tag=b#intro")
+	fmt.Println(parts["hostname"], parts["port"], parts["path"], parts["fragment"])
+	tags, _ := filter.Extract(parts, "queryValues.tag")
+	fmt.Println(tags)
+	// Output:
+	// example.com 8080 /docs intro
+	// [a b]
+}
+
+func ExampleSetQueryParam() {
+	result, _ := filter.SetQueryParam("/search?q=go&page=1#results", "page", "2")
+	fmt.Println(result)
+	result, _ = filter.RemoveQueryParam(result, "page")
+	fmt.Println(result)
+	// Output:
+	// /search?q=go&page=2#results
+	// /search?q=go#results
+}
+
+func ExampleJoinURLPath() {
+	result, _ := filter.JoinURLPath("https://example.com/api/", "/v1/", "files", "my report.pdf")
+	fmt.Println(result)
+	// Output: https://example.com/api/v1/files/my%20report.pdf
+}
+
 func ExampleDefault() {
 	fmt.Printf("%q\n", filter.Default("", "fallback"))
 	fmt.Printf("%q\n", filter.Default(nil, "fallback"))
@@ -182,6 +447,28 @@ func ExampleBase64Decode() {
 	// Output: hello world
 }
 
+func ExampleBase58Encode() {
+	fmt.Println(filter.Base58Encode("Hello World!"))
+	// Output: 2NEpo7TZRRrLZSi2U
+}
+
+func ExamplePunycodeEncode() {
+	result, _ := filter.PunycodeEncode("Bücher.example")
+	fmt.Println(result)
+	// Output: xn--bcher-kva.example
+}
+
+func ExampleSHA256() {
+	fmt.Println(filter.SHA256("abc"))
+	// Output: ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad
+}
+
+func ExampleHMAC() {
+	result, _ := filter.HMAC("what do ya want for nothing?", "Jefe", "sha256", "hex")
+	fmt.Println(result)
+	// Output: 5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843
+}
+
 func ExampleSort() {
 	result, _ := filter.Sort([]any{"banana", "apple", "cherry"})
 	fmt.Println(result)
@@ -0,0 +1,243 @@
+package filter
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// ExcerptOptions selects how ExcerptWithOptions measures its window and
+// matches the phrase. The zero value behaves exactly like Excerpt.
+type ExcerptOptions struct {
+	// Runes measures the window in runes instead of words. The window may
+	// then start or end inside a word.
+	Runes bool
+	// Fold matches the phrase ignoring case and accents, comparing text as
+	// CaseFold followed by Unaccent would, so "creme" finds "Crème". Marks
+	// that Unaccent keeps, such as Japanese dakuten, still have to match.
+	Fold bool
+}
+
+// HighlightOptions selects how HighlightWithOptions matches the phrase.
+// The zero value behaves exactly like Highlight.
+type HighlightOptions struct {
+	// Fold matches the phrase ignoring case and accents, as in
+	// ExcerptOptions.
+	Fold bool
+}
+
+// Excerpt returns a window of size words, as WordCount counts them, around
+// the first occurrence of phrase in input, for search results such as
+// "...text around the matched term...". The phrase is matched exactly and
+// sits in the middle of the window; near either end of input the window
+// shifts so it still holds size words when input has them. A phrase longer
+// than the window is returned whole. The ellipsis, by default "...", marks
+// each side where words were cut; an explicit empty string disables it.
+//
+// When phrase is empty or not found, Excerpt returns the first size words.
+// A size of zero or less returns "". The result is plain text; pass it
+// through Highlight to mark the phrase and escape it for HTML.
+func Excerpt(input, phrase string, size int, ellipsis ...string) string {
+	return ExcerptWithOptions(input, phrase, size, ExcerptOptions{}, ellipsis...)
+}
+
+// ExcerptWithOptions is Excerpt with the window unit and phrase matching
+// selected by opts.
+func ExcerptWithOptions(input, phrase string, size int, opts ExcerptOptions, ellipsis ...string) string {
+	omission := truncateOmission(ellipsis)
+	if size <= 0 {
+		return ""
+	}
+	match := textSpan{0, 0}
+	if found := findMatches(input, phrase, opts.Fold, 1); len(found) > 0 {
+		match = found[0]
+	}
+
+	var start, end int
+	if opts.Runes {
+		start, end = runeWindow(input, match, size)
+	} else {
+		start, end = wordWindow(input, match, size)
+	}
+	// Punctuation at either end of input, such as a final period, stays
+	// with the excerpt; elsewhere the ellipsis replaces the cut text.
+	prefix, suffix := omission, omission
+	if strings.IndexFunc(input[:start], isWordPart) < 0 {
+		start, prefix = 0, ""
+	}
+	if strings.IndexFunc(input[end:], isWordPart) < 0 {
+		end, suffix = len(input), ""
+	}
+	return prefix + strings.TrimSpace(input[start:end]) + suffix
+}
+
+// wordWindow returns the byte range of the size words centered on match,
+// widened to whole words at both ends of the match.
+func wordWindow(input string, match textSpan, size int) (start, end int) {
+	words := wordSpans(input)
+	// Words [first, last) overlap the match. When none do, first == last
+	// is where the match sits between words.
+	first := 0
+	for first < len(words) && words[first].end <= match.start {
+		first++
+	}
+	last := first
+	for last < len(words) && words[last].start < match.end {
+		last++
+	}
+	lo, hi := centerWindow(first, last, len(words), size)
+
+	start, end = match.start, match.end
+	if lo < last && words[lo].start < start {
+		start = words[lo].start
+	}
+	if hi > first && words[hi-1].end > end {
+		end = words[hi-1].end
+	}
+	return start, end
+}
+
+// runeWindow returns the byte range of the size runes centered on match.
+func runeWindow(input string, match textSpan, size int) (start, end int) {
+	first := utf8.RuneCountInString(input[:match.start])
+	last := first + utf8.RuneCountInString(input[match.start:match.end])
+	lo, hi := centerWindow(first, last, utf8.RuneCountInString(input), size)
+
+	start, end = len(input), len(input)
+	n := 0
+	for i := range input {
+		if n == lo {
+			start = i
+		}
+		if n == hi {
+			end = i
+			break
+		}
+		n++
+	}
+	return start, end
+}
+
+// centerWindow returns the units [lo, hi) of a window of size units out of
+// total that holds [first, last) in its middle. Units a side cannot take
+// because it reaches the start or end of the text go to the other side.
+func centerWindow(first, last, total, size int) (lo, hi int) {
+	extra := max(size-(last-first), 0)
+	lo = first - extra/2
+	hi = last + extra - extra/2
+	if lo < 0 {
+		hi -= lo
+		lo = 0
+	}
+	if hi > total {
+		lo = max(lo-(hi-total), 0)
+		hi = total
+	}
+	return lo, hi
+}
+
+// Highlight escapes input with Escape and wraps every occurrence of phrase
+// in the openMark and closeMark markers, such as "<mark>" and "</mark>",
+// which are written as they are. Occurrences are matched exactly, from left
+// to right without overlapping. An empty phrase only escapes input.
+func Highlight(input, phrase, openMark, closeMark string) string {
+	return HighlightWithOptions(input, phrase, openMark, closeMark, HighlightOptions{})
+}
+
+// HighlightWithOptions is Highlight with the phrase matching selected by
+// opts.
+func HighlightWithOptions(input, phrase, openMark, closeMark string, opts HighlightOptions) string {
+	var b strings.Builder
+	prev := 0
+	for _, match := range findMatches(input, phrase, opts.Fold, -1) {
+		b.WriteString(Escape(input[prev:match.start]))
+		b.WriteString(openMark)
+		b.WriteString(Escape(input[match.start:match.end]))
+		b.WriteString(closeMark)
+		prev = match.end
+	}
+	b.WriteString(Escape(input[prev:]))
+	return b.String()
+}
+
+// findMatches returns up to limit non-overlapping occurrences of phrase in
+// input, or all of them when limit is negative. With fold, input and
+// phrase are compared one grapheme cluster at a time after CaseFold and
+// Unaccent, and a match must cover whole clusters of input, so a match
+// never splits a letter from its accent.
+func findMatches(input, phrase string, fold bool, limit int) []textSpan {
+	var matches []textSpan
+	if !fold {
+		if phrase == "" {
+			return nil
+		}
+		for from := 0; limit < 0 || len(matches) < limit; {
+			i := strings.Index(input[from:], phrase)
+			if i < 0 {
+				break
+			}
+			start := from + i
+			matches = append(matches, textSpan{start, start + len(phrase)})
+			from = start + len(phrase)
+		}
+		return matches
+	}
+
+	text := foldText(input)
+	key := foldText(phrase).folded
+	if key == "" {
+		return nil
+	}
+	for from := 0; limit < 0 || len(matches) < limit; {
+		i := strings.Index(text.folded[from:], key)
+		if i < 0 {
+			break
+		}
+		start, end := from+i, from+i+len(key)
+		if !text.clusterStart(start) || !text.clusterStart(end) {
+			from = start + 1
+			continue
+		}
+		matches = append(matches, textSpan{text.origin[start].start, text.origin[end-1].end})
+		from = end
+	}
+	return matches
+}
+
+// foldedText is a string folded for caseless, accent-insensitive matching,
+// with origin holding, for each byte of folded, the byte range of the
+// grapheme cluster of the original string it came from.
+type foldedText struct {
+	folded string
+	origin []textSpan
+}
+
+func foldText(s string) foldedText {
+	var b strings.Builder
+	origin := make([]textSpan, 0, len(s))
+	for i := 0; i < len(s); {
+		n := nextGraphemeLen(s[i:])
+		cluster := s[i : i+n]
+		var f string
+		if c := cluster[0]; n == 1 && c < utf8.RuneSelf {
+			// ASCII needs neither case folding tables nor normalization.
+			if 'A' <= c && c <= 'Z' {
+				c += 'a' - 'A'
+			}
+			f = string(c)
+		} else {
+			f = Unaccent(CaseFold(cluster))
+		}
+		b.WriteString(f)
+		for range len(f) {
+			origin = append(origin, textSpan{i, i + n})
+		}
+		i += n
+	}
+	return foldedText{folded: b.String(), origin: origin}
+}
+
+// clusterStart reports whether byte i of the folded text starts the folded
+// form of a cluster, or is the end of the text.
+func (t foldedText) clusterStart(i int) bool {
+	return i == 0 || i == len(t.origin) || t.origin[i].start != t.origin[i-1].start
+}
@@ -0,0 +1,184 @@
+package filter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const excerptText = "The quick brown fox jumps over the lazy dog while the farmer sleeps in the barn."
+
+func TestExcerpt(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		input    string
+		phrase   string
+		size     int
+		ellipsis []string
+		want     string
+	}{
+		{"Centered", excerptText, "lazy", 5, nil, "...over the lazy dog while..."},
+		{"Even Size Extra Word After", excerptText, "lazy", 4, nil, "...the lazy dog while..."},
+		{"Phrase Of Several Words", excerptText, "lazy dog", 4, nil, "...the lazy dog while..."},
+		{"Near Start Shifts Right", excerptText, "quick", 5, nil, "The quick brown fox jumps..."},
+		{"At Start", excerptText, "The", 3, nil, "The quick brown..."},
+		{"Near End Shifts Left", excerptText, "barn", 5, nil, "...farmer sleeps in the barn."},
+		{"Phrase Longer Than Window", excerptText, "fox jumps over", 1, nil, "...fox jumps over..."},
+		{"Whole Text Fits", "Short text here", "text", 10, nil, "Short text here"},
+		{"Match Inside Word", excerptText, "arm", 3, nil, "...the farmer sleeps..."},
+		{"Not Found", excerptText, "cat", 3, nil, "The quick brown..."},
+		{"Empty Phrase", excerptText, "", 2, nil, "The quick..."},
+		{"Case Sensitive", excerptText, "LAZY", 2, nil, "The quick..."},
+		{"Custom Ellipsis", excerptText, "lazy", 3, []string{"…"}, "…the lazy dog…"},
+		{"Empty Ellipsis", excerptText, "lazy", 3, []string{""}, "the lazy dog"},
+		{"First Occurrence", "a cat, then another cat here", "cat", 1, nil, "...cat..."},
+		{"Zero Size", excerptText, "lazy", 0, nil, ""},
+		{"Negative Size", excerptText, "lazy", -1, nil, ""},
+		{"Empty Input", "", "lazy", 3, nil, ""},
+		{"Surrounding Space Trimmed", "  one two  ", "one", 5, nil, "one two"},
+		{"Edge Punctuation Kept", `"Stop!" he said.`, "said", 1, nil, "...said."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tt.want, Excerpt(tt.input, tt.phrase, tt.size, tt.ellipsis...))
+		})
+	}
+}
+
+func TestExcerptWithOptions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		input  string
+		phrase string
+		size   int
+		opts   ExcerptOptions
+		want   string
+	}{
+		{"Zero Options", excerptText, "lazy", 5, ExcerptOptions{}, Excerpt(excerptText, "lazy", 5)},
+		{"Fold Case", excerptText, "LAZY", 3, ExcerptOptions{Fold: true}, "...the lazy dog..."},
+		{"Fold Accents", "Order the Crème Brûlée for dessert tonight", "creme brulee", 2, ExcerptOptions{Fold: true}, "...Crème Brûlée..."},
+		{"Fold Accents In Phrase", "Order the creme brulee for dessert", "Crème", 3, ExcerptOptions{Fold: true}, "...the creme brulee..."},
+		{"Fold Sharp S", "Die große Straße ist lang", "STRASSE", 1, ExcerptOptions{Fold: true}, "...Straße..."},
+		{"Runes", excerptText, "lazy", 12, ExcerptOptions{Runes: true}, "...the lazy dog..."},
+		{"Runes Near Start", excerptText, "The", 9, ExcerptOptions{Runes: true}, "The quick..."},
+		{"Runes Near End", excerptText, "barn", 10, ExcerptOptions{Runes: true}, "...the barn."},
+		{"Runes Multibyte", "日本語のテキストです", "テキスト", 6, ExcerptOptions{Runes: true}, "...のテキストで..."},
+		{"Runes Not Found", excerptText, "cat", 9, ExcerptOptions{Runes: true}, "The quick..."},
+		{"Runes And Fold", "Visit MÜNCHEN today", "munchen", 9, ExcerptOptions{Runes: true, Fold: true}, "...MÜNCHEN..."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tt.want, ExcerptWithOptions(tt.input, tt.phrase, tt.size, tt.opts))
+		})
+	}
+}
+
+func TestHighlight(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		input  string
+		phrase string
+		want   string
+	}{
+		{"Single", "the lazy dog", "lazy", "the <mark>lazy</mark> dog"},
+		{"Every Occurrence", "cat and cat", "cat", "<mark>cat</mark> and <mark>cat</mark>"},
+		{"No Overlap", "aaa", "aa", "<mark>aa</mark>a"},
+		{"Surrounding Text Escaped", `<b>"Tom" & Jerry</b>`, "Jerry", "&lt;b&gt;&#34;Tom&#34; &amp; <mark>Jerry</mark>&lt;/b&gt;"},
+		{"Match Escaped", "a<b>c", "<b>", "a<mark>&lt;b&gt;</mark>c"},
+		{"Case Sensitive", "Go go GO", "go", "Go <mark>go</mark> GO"},
+		{"Not Found", "a & b", "c", "a &amp; b"},
+		{"Empty Phrase", "a & b", "", "a &amp; b"},
+		{"Empty Input", "", "a", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tt.want, Highlight(tt.input, tt.phrase, "<mark>", "</mark>"))
+		})
+	}
+}
+
+func TestHighlightWithOptions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		input  string
+		phrase string
+		opts   HighlightOptions
+		want   string
+	}{
+		{"Zero Options", "Go go", "go", HighlightOptions{}, "Go [go]"},
+		{"Fold Case", "Go go GO", "go", HighlightOptions{Fold: true}, "[Go] [go] [GO]"},
+		{"Fold Accents", "Café, cafe, CAFÉ", "cafe", HighlightOptions{Fold: true}, "[Café], [cafe], [CAFÉ]"},
+		{"Fold Decomposed Accent", "café time", "CAFÉ", HighlightOptions{Fold: true}, "[café] time"},
+		{"Fold Sharp S", "Straße and STRASSE", "strasse", HighlightOptions{Fold: true}, "[Straße] and [STRASSE]"},
+		{"Fold Keeps Clusters Whole", "Straße", "s", HighlightOptions{Fold: true}, "[S]traße"},
+		{"Fold Escapes", "<Tom> & tom", "TOM", HighlightOptions{Fold: true}, "&lt;[Tom]&gt; &amp; [tom]"},
+		{"Fold Empty Phrase", "a < b", "", HighlightOptions{Fold: true}, "a &lt; b"},
+		{"Fold Keeps Kana Marks", "かがか", "か", HighlightOptions{Fold: true}, "[か]が[か]"},
+		{"Fold Keeps Devanagari Signs", "हिन्दी हनद", "हनद", HighlightOptions{Fold: true}, "हिन्दी [हनद]"},
+		{"Fold Keeps Hebrew Points", "שָׁלוֹם שלום", "שלום", HighlightOptions{Fold: true}, "שָׁלוֹם [שלום]"},
+		{"Fold Greek Accents", "Ελληνικά", "ελληνικα", HighlightOptions{Fold: true}, "[Ελληνικά]"},
+		{"Fold Mark Only Phrase", "a b", "\u0301", HighlightOptions{Fold: true}, "a b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tt.want, HighlightWithOptions(tt.input, tt.phrase, "[", "]", tt.opts))
+		})
+	}
+}
+
+func FuzzHighlight(f *testing.F) {
+	f.Add("Crème Brûlée & <b>CREME</b>", "creme", true)
+	f.Add("Straße strasse", "SS", true)
+	f.Add("aaa", "aa", false)
+	f.Add("é\xff", "e", true)
+	f.Fuzz(func(t *testing.T, input, phrase string, fold bool) {
+		matches := findMatches(input, phrase, fold, -1)
+		prev := 0
+		for _, m := range matches {
+			if m.start < prev || m.end <= m.start || m.end > len(input) {
+				t.Fatalf("findMatches(%q, %q, %v) = %v: bad span", input, phrase, fold, matches)
+			}
+			if !fold && input[m.start:m.end] != phrase {
+				t.Fatalf("findMatches(%q, %q) matched %q", input, phrase, input[m.start:m.end])
+			}
+			prev = m.end
+		}
+
+		const openMark, closeMark = "\x00", "\x01"
+		got := HighlightWithOptions(input, phrase, openMark, closeMark, HighlightOptions{Fold: fold})
+		if n := strings.Count(got, openMark); n < len(matches) {
+			t.Fatalf("Highlight(%q, %q) = %q has %d markers, want %d", input, phrase, got, n, len(matches))
+		}
+		plain := strings.NewReplacer(openMark, "", closeMark, "").Replace(got)
+		if want := strings.NewReplacer(openMark, "", closeMark, "").Replace(Escape(input)); plain != want {
+			t.Fatalf("Highlight(%q, %q) = %q changes the escaped text %q", input, phrase, got, want)
+		}
+
+		for _, size := range []int{1, 4} {
+			excerpt := ExcerptWithOptions(input, phrase, size, ExcerptOptions{Fold: fold}, "")
+			if len(matches) > 0 && !strings.Contains(excerpt, strings.TrimSpace(input[matches[0].start:matches[0].end])) {
+				t.Fatalf("Excerpt(%q, %q, %d) = %q misses the match", input, phrase, size, excerpt)
+			}
+			if !strings.Contains(input, excerpt) {
+				t.Fatalf("Excerpt(%q, %q, %d) = %q is not part of input", input, phrase, size, excerpt)
+			}
+		}
+	})
+}
@@ -3,19 +3,23 @@ module github.com/kaptinlin/filter
 go 1.26.4
 
 require (
+	github.com/BurntSushi/toml v1.6.0
 	github.com/agentable/go-humanize v0.1.2
 	github.com/agentable/go-time v0.6.3
+	github.com/cespare/xxhash/v2 v2.3.0
 	github.com/google/go-cmp v0.7.0
 	github.com/gosimple/slug v1.15.0
+	github.com/gosimple/unidecode v1.0.1
 	github.com/jinzhu/inflection v1.0.0
 	github.com/stretchr/testify v1.11.1
+	github.com/yuin/goldmark v1.8.2
+	golang.org/x/net v0.57.0
+	golang.org/x/text v0.40.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/go-json-experiment/json v0.0.0-20260623181947-01eb4420fa68 // indirect
-	github.com/gosimple/unidecode v1.0.1 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	golang.org/x/text v0.38.0 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -0,0 +1,238 @@
+package filter
+
+import (
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// Graphemes splits input into extended grapheme clusters, the units a
+// reader perceives as single characters. Segmentation follows the default
+// rules of Unicode Standard Annex #29 using Unicode 15.0.0 property data
+// compiled into this package, so a flag, an emoji ZWJ sequence, a base letter
+// with combining accents, or CRLF each form one cluster.
+//
+// Invalid UTF-8 bytes are returned as single-byte clusters.
+func Graphemes(input string) []string {
+	out := make([]string, 0, len(input))
+	for len(input) > 0 {
+		n := nextGraphemeLen(input)
+		out = append(out, input[:n])
+		input = input[n:]
+	}
+	return out
+}
+
+// GraphemeLength returns the number of extended grapheme clusters in input.
+// Unlike Length it counts "👍🏽" or "é" written as e plus U+0301 as one.
+func GraphemeLength(input string) int {
+	count := 0
+	for len(input) > 0 {
+		input = input[nextGraphemeLen(input):]
+		count++
+	}
+	return count
+}
+
+// GraphemeSlice is the grapheme-cluster counterpart of Slice for strings:
+// offset and length count clusters instead of runes. Negative offsets count
+// from the end, length defaults to 1, and out-of-range input returns "".
+func GraphemeSlice(input string, offset int, length ...int) string {
+	clusters := Graphemes(input)
+	start, end, ok := sliceBounds(len(clusters), offset, length...)
+	if !ok {
+		return ""
+	}
+	return strings.Join(clusters[start:end], "")
+}
+
+// GraphemeTruncate is the grapheme-cluster counterpart of Truncate: it
+// shortens input to maxLength clusters, including the ellipsis, without
+// splitting a cluster. The ellipsis defaults to "..." as in Truncate.
+func GraphemeTruncate(input string, maxLength int, ellipsis ...string) string {
+	omission := truncateOmission(ellipsis)
+	if maxLength <= 0 {
+		return ""
+	}
+	clusters := Graphemes(input)
+	if len(clusters) <= maxLength {
+		return input
+	}
+	omissionClusters := Graphemes(omission)
+	if maxLength <= len(omissionClusters) {
+		return strings.Join(omissionClusters[:maxLength], "")
+	}
+	return strings.Join(clusters[:maxLength-len(omissionClusters)], "") + omission
+}
+
+// GraphemeReverse reverses the order of the grapheme clusters in input while
+// keeping each cluster intact, so combining marks stay on their base
+// character and emoji sequences are not broken apart.
+func GraphemeReverse(input string) string {
+	var b strings.Builder
+	b.Grow(len(input))
+	for len(input) > 0 {
+		start := len(input) - lastGraphemeLen(input)
+		b.WriteString(input[start:])
+		input = input[:start]
+	}
+	return b.String()
+}
+
+// lastGraphemeLen returns the byte length of the final cluster of s by
+// segmenting forward; grapheme rules cannot be applied reliably backwards.
+func lastGraphemeLen(s string) int {
+	last := 0
+	for rest := s; len(rest) > 0; {
+		last = nextGraphemeLen(rest)
+		rest = rest[last:]
+	}
+	return last
+}
+
+// graphemeBreak is a Grapheme_Cluster_Break property value, with
+// Extended_Pictographic added as its own value.
+type graphemeBreak uint8
+
+const (
+	gbOther graphemeBreak = iota
+	gbCR
+	gbLF
+	gbControl
+	gbExtend
+	gbZWJ
+	gbRegionalIndicator
+	gbPrepend
+	gbSpacingMark
+	gbL
+	gbV
+	gbT
+	gbLV
+	gbLVT
+	gbExtendedPictographic
+)
+
+type graphemeBreakRange struct {
+	lo, hi rune
+	prop   graphemeBreak
+}
+
+const (
+	hangulSyllableFirst = 0xAC00
+	hangulSyllableLast  = 0xD7A3
+	hangulTCount        = 28
+)
+
+func graphemeBreakOf(r rune) graphemeBreak {
+	switch {
+	case r == '\r':
+		return gbCR
+	case r == '\n':
+		return gbLF
+	case r < 0x20 || r == 0x7F:
+		return gbControl
+	case r < 0x7F:
+		return gbOther
+	case hangulSyllableFirst <= r && r <= hangulSyllableLast:
+		return hangulSyllableBreak(r)
+	}
+	i, found := slices.BinarySearchFunc(graphemeBreakRanges[:], r, func(e graphemeBreakRange, r rune) int {
+		switch {
+		case r < e.lo:
+			return 1
+		case r > e.hi:
+			return -1
+		default:
+			return 0
+		}
+	})
+	if !found {
+		return gbOther
+	}
+	return graphemeBreakRanges[i].prop
+}
+
+// hangulSyllableBreak classifies a precomposed Hangul syllable: syllables
+// without a trailing consonant are LV, the rest LVT.
+func hangulSyllableBreak(r rune) graphemeBreak {
+	if (r-hangulSyllableFirst)%hangulTCount == 0 {
+		return gbLV
+	}
+	return gbLVT
+}
+
+// nextGraphemeLen returns the byte length of the first extended grapheme
+// cluster in s, which must be non-empty.
+func nextGraphemeLen(s string) int {
+	r, n := utf8.DecodeRuneInString(s)
+	prev := graphemeBreakOf(r)
+	// pictographic reports that the cluster so far ends in
+	// Extended_Pictographic Extend*, and zwjAfterPictographic that it ends in
+	// that sequence followed by ZWJ (rule GB11).
+	pictographic := prev == gbExtendedPictographic
+	zwjAfterPictographic := false
+	// regionalIndicators counts the trailing run of regional indicators
+	// (rules GB12 and GB13).
+	regionalIndicators := 0
+	if prev == gbRegionalIndicator {
+		regionalIndicators = 1
+	}
+
+	for n < len(s) {
+		r, size := utf8.DecodeRuneInString(s[n:])
+		next := graphemeBreakOf(r)
+		if graphemeBoundary(prev, next, zwjAfterPictographic, regionalIndicators) {
+			break
+		}
+
+		switch next {
+		case gbExtendedPictographic:
+			pictographic, zwjAfterPictographic = true, false
+		case gbExtend:
+			zwjAfterPictographic = false
+		case gbZWJ:
+			pictographic, zwjAfterPictographic = false, pictographic
+		default:
+			pictographic, zwjAfterPictographic = false, false
+		}
+		if next == gbRegionalIndicator {
+			regionalIndicators++
+		} else {
+			regionalIndicators = 0
+		}
+
+		prev = next
+		n += size
+	}
+	return n
+}
+
+// graphemeBoundary applies rules GB3 through GB999 to the pair (prev, next).
+func graphemeBoundary(prev, next graphemeBreak, zwjAfterPictographic bool, regionalIndicators int) bool {
+	switch {
+	case prev == gbCR && next == gbLF: // GB3
+		return false
+	case prev == gbCR || prev == gbLF || prev == gbControl: // GB4
+		return true
+	case next == gbCR || next == gbLF || next == gbControl: // GB5
+		return true
+	case prev == gbL && (next == gbL || next == gbV || next == gbLV || next == gbLVT): // GB6
+		return false
+	case (prev == gbLV || prev == gbV) && (next == gbV || next == gbT): // GB7
+		return false
+	case (prev == gbLVT || prev == gbT) && next == gbT: // GB8
+		return false
+	case next == gbExtend || next == gbZWJ: // GB9
+		return false
+	case next == gbSpacingMark: // GB9a
+		return false
+	case prev == gbPrepend: // GB9b
+		return false
+	case prev == gbZWJ && next == gbExtendedPictographic && zwjAfterPictographic: // GB11
+		return false
+	case prev == gbRegionalIndicator && next == gbRegionalIndicator: // GB12, GB13
+		return regionalIndicators%2 == 0
+	default: // GB999
+		return true
+	}
+}
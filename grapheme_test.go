@@ -0,0 +1,188 @@
+package filter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	familyZWJ    = "\U0001F468\u200D\U0001F469\u200D\U0001F467\u200D\U0001F466" // 👨‍👩‍👧‍👦 family
+	thumbsMedium = "\U0001F44D\U0001F3FD"                                       // 👍🏽
+	flagJP       = "\U0001F1EF\U0001F1F5"                                       // 🇯🇵
+	flagUS       = "\U0001F1FA\U0001F1F8"                                       // 🇺🇸
+	eAcute       = "e\u0301"                                                    // é, decomposed
+	rainbowFlag  = "\U0001F3F3\uFE0F\u200D\U0001F308"                           // rainbow flag
+)
+
+func TestGraphemes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{"Empty", "", []string{}},
+		{"ASCII", "abc", []string{"a", "b", "c"}},
+		{"CRLF Is One Cluster", "a\r\nb", []string{"a", "\r\n", "b"}},
+		{"LFCR Is Two Clusters", "\n\r", []string{"\n", "\r"}},
+		{"Combining Accent", "caf" + eAcute, []string{"c", "a", "f", eAcute}},
+		{"Stacked Combining Marks", "a\u0308\u0301b", []string{"a\u0308\u0301", "b"}},
+		{"Skin Tone Modifier", thumbsMedium + "!", []string{thumbsMedium, "!"}},
+		{"ZWJ Family", familyZWJ, []string{familyZWJ}},
+		{"ZWJ With Variation Selector", rainbowFlag, []string{rainbowFlag}},
+		{"ZWJ After Non Pictographic", "a\u200D\U0001F308", []string{"a\u200D", "\U0001F308"}},
+		{"Flag Pair", flagJP + flagUS, []string{flagJP, flagUS}},
+		{"Odd Regional Indicator", flagJP + "\U0001F1FA", []string{flagJP, "\U0001F1FA"}},
+		{"Hangul Jamo", "\u1100\u1161\u11A8", []string{"\u1100\u1161\u11A8"}},
+		{"Hangul Syllable With Trailing Jamo", "가\u11A8각", []string{"가\u11A8", "각"}},
+		{"Devanagari Spacing Mark", "न\u093F", []string{"न\u093F"}},
+		{"Prepend", "\u0600a", []string{"\u0600a"}},
+		{"Control Breaks", "a\u0001\u0301", []string{"a", "\u0001", "\u0301"}},
+		{"Invalid UTF-8", "a\xffb", []string{"a", "\xff", "b"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := Graphemes(tt.input)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("Graphemes(%q) mismatch (-want +got):\n%s", tt.input, diff)
+			}
+			require.Equal(t, len(tt.want), GraphemeLength(tt.input))
+			require.Equal(t, tt.input, strings.Join(got, ""))
+		})
+	}
+}
+
+func TestGraphemeLength(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input string
+		want  int
+	}{
+		{"", 0},
+		{"hello", 5},
+		{"héllo", 5},
+		{"h" + eAcute + "llo", 5},
+		{"Hi " + thumbsMedium, 4},
+		{familyZWJ + familyZWJ, 2},
+		{flagJP + flagUS + flagJP, 3},
+		{"こんにちは", 5},
+	}
+
+	for _, tt := range tests {
+		require.Equal(t, tt.want, GraphemeLength(tt.input), "GraphemeLength(%q)", tt.input)
+	}
+	require.Equal(t, 7, Length(familyZWJ))
+}
+
+func TestGraphemeSlice(t *testing.T) {
+	t.Parallel()
+
+	name := "Zoë " + familyZWJ + " " + flagJP
+
+	tests := []struct {
+		name   string
+		input  string
+		offset int
+		length []int
+		want   string
+	}{
+		{"Default Length", name, 4, nil, familyZWJ},
+		{"With Length", name, 0, []int{3}, "Zoë"},
+		{"Negative Offset", name, -1, nil, flagJP},
+		{"Negative Offset With Length", name, -3, []int{3}, familyZWJ + " " + flagJP},
+		{"Combining Accent Kept", "caf" + eAcute + "s", 3, []int{1}, eAcute},
+		{"Length Past End", name, 5, []int{10}, " " + flagJP},
+		{"Offset Out Of Range", name, 10, nil, ""},
+		{"Zero Length", name, 0, []int{0}, ""},
+		{"Empty Input", "", 0, nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tt.want, GraphemeSlice(tt.input, tt.offset, tt.length...))
+		})
+	}
+}
+
+func TestGraphemeTruncate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		input     string
+		maxLength int
+		ellipsis  []string
+		want      string
+	}{
+		{"Fits", "Hi " + thumbsMedium, 4, nil, "Hi " + thumbsMedium},
+		{"ZWJ Sequence Not Split", familyZWJ + familyZWJ + familyZWJ + familyZWJ + familyZWJ, 4, nil, familyZWJ + "..."},
+		{"Flags Not Split", flagJP + flagUS + flagJP + flagUS, 3, []string{"…"}, flagJP + flagUS + "…"},
+		{"Combining Accent Not Split", "caf" + eAcute + " au lait", 5, []string{"…"}, "caf" + eAcute + "…"},
+		{"Ellipsis Longer Than Limit", "Hello", 2, nil, ".."},
+		{"Empty Ellipsis", "Hello " + thumbsMedium, 6, []string{""}, "Hello "},
+		{"Zero Length", "Hello", 0, nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tt.want, GraphemeTruncate(tt.input, tt.maxLength, tt.ellipsis...))
+		})
+	}
+}
+
+func TestGraphemeReverse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"", ""},
+		{"abc", "cba"},
+		{"caf" + eAcute, eAcute + "fac"},
+		{"a" + familyZWJ + "b", "b" + familyZWJ + "a"},
+		{flagJP + flagUS, flagUS + flagJP},
+		{"x\r\ny", "y\r\nx"},
+	}
+
+	for _, tt := range tests {
+		require.Equal(t, tt.want, GraphemeReverse(tt.input), "GraphemeReverse(%q)", tt.input)
+	}
+}
+
+func FuzzGraphemes(f *testing.F) {
+	f.Add("Zoë " + familyZWJ + flagJP)
+	f.Add("a\r\n\u0301")
+	f.Add("\xff\U0001F1EF")
+	f.Fuzz(func(t *testing.T, input string) {
+		clusters := Graphemes(input)
+		if strings.Join(clusters, "") != input {
+			t.Fatalf("Graphemes(%q) = %q does not reassemble the input", input, clusters)
+		}
+		if GraphemeLength(input) != len(clusters) {
+			t.Fatalf("GraphemeLength(%q) disagrees with Graphemes", input)
+		}
+		if len(GraphemeReverse(input)) != len(input) {
+			t.Fatalf("GraphemeReverse(%q) changed the byte length", input)
+		}
+	})
+}
+
+func BenchmarkGraphemeLength(b *testing.B) {
+	input := strings.Repeat("Zoë "+familyZWJ+" "+flagJP+" hello world ", 8)
+	for b.Loop() {
+		GraphemeLength(input)
+	}
+}
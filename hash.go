@@ -0,0 +1,133 @@
+package filter
+
+import (
+	"crypto/hmac"
+	"crypto/md5"  // #nosec G501 -- offered for cache keys and checksums, not security.
+	"crypto/sha1" // #nosec G505 -- offered for cache keys and checksums, not security.
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"strings"
+
+	"github.com/cespare/xxhash/v2"
+)
+
+// MD5 returns the MD5 digest of input as 32 lower-case hex digits. MD5 is
+// broken for security purposes; use it only for cache keys and checksums.
+func MD5(input string) string {
+	// #nosec G401 -- documented as unfit for security purposes.
+	sum := md5.Sum([]byte(input))
+	return hex.EncodeToString(sum[:])
+}
+
+// SHA1 returns the SHA-1 digest of input as 40 lower-case hex digits. SHA-1
+// is broken for security purposes; use it only for cache keys and
+// checksums.
+func SHA1(input string) string {
+	// #nosec G401 -- documented as unfit for security purposes.
+	sum := sha1.Sum([]byte(input))
+	return hex.EncodeToString(sum[:])
+}
+
+// SHA256 returns the SHA-256 digest of input as 64 lower-case hex digits.
+func SHA256(input string) string {
+	sum := sha256.Sum256([]byte(input))
+	return hex.EncodeToString(sum[:])
+}
+
+// SHA512 returns the SHA-512 digest of input as 128 lower-case hex digits.
+func SHA512(input string) string {
+	sum := sha512.Sum512([]byte(input))
+	return hex.EncodeToString(sum[:])
+}
+
+// CRC32 returns the CRC-32 (IEEE) checksum of input as 8 lower-case hex
+// digits.
+func CRC32(input string) string {
+	return fmt.Sprintf("%08x", crc32.ChecksumIEEE([]byte(input)))
+}
+
+// XXHash returns the 64-bit xxHash (XXH64, seed 0) of input as 16
+// lower-case hex digits. It is much faster than the cryptographic hashes
+// and suits cache keys, but it is not collision resistant against
+// deliberate attacks.
+func XXHash(input string) string {
+	return fmt.Sprintf("%016x", xxhash.Sum64String(input))
+}
+
+// hashAlgorithms maps the algorithm names accepted by Hash and HMAC to
+// their constructors. keyed reports whether HMAC accepts the algorithm.
+var hashAlgorithms = map[string]struct {
+	new   func() hash.Hash
+	keyed bool
+}{
+	"md5":      {md5.New, true},
+	"sha1":     {sha1.New, true},
+	"sha256":   {sha256.New, true},
+	"sha512":   {sha512.New, true},
+	"crc32":    {func() hash.Hash { return crc32.NewIEEE() }, false},
+	"xxhash":   {func() hash.Hash { return xxhash.New() }, false},
+	"xxhash64": {func() hash.Hash { return xxhash.New() }, false},
+}
+
+// Hash returns the digest of input with the named algorithm, written in
+// the named encoding. Both names are case-insensitive.
+//
+// algorithm is one of "md5", "sha1", "sha256", "sha512", "crc32", or
+// "xxhash" (also "xxhash64"). encoding is "hex" (lower case, the default
+// when empty), "base64" (standard, padded), or "base64url" (URL-safe,
+// unpadded, for use in URLs and file names). Digests are written
+// big-endian, so Hash(s, "crc32", "hex") equals CRC32(s). Any other name
+// returns *Error{Kind: KindInvalidInput}.
+func Hash(input, algorithm, encoding string) (string, error) {
+	alg, ok := hashAlgorithms[strings.ToLower(algorithm)]
+	if !ok {
+		return "", invalidInput("Hash", fmt.Errorf("unsupported algorithm %q", algorithm))
+	}
+	h := alg.new()
+	h.Write([]byte(input))
+	out, err := encodeDigest(h.Sum(nil), encoding)
+	if err != nil {
+		return "", invalidInput("Hash", err)
+	}
+	return out, nil
+}
+
+// HMAC returns the HMAC of input under key with the named hash algorithm,
+// written in the named encoding, for signing URLs and similar tokens. The
+// key is always passed explicitly; it is never read from the environment.
+//
+// algorithm is one of "md5", "sha1", "sha256", or "sha512", and encoding is
+// one of the encodings Hash accepts. Any other name returns
+// *Error{Kind: KindInvalidInput}. Compare signatures with hmac.Equal, not
+// ==, to avoid timing leaks.
+func HMAC(input, key, algorithm, encoding string) (string, error) {
+	alg, ok := hashAlgorithms[strings.ToLower(algorithm)]
+	if !ok || !alg.keyed {
+		return "", invalidInput("HMAC", fmt.Errorf("unsupported algorithm %q", algorithm))
+	}
+	mac := hmac.New(alg.new, []byte(key))
+	mac.Write([]byte(input))
+	out, err := encodeDigest(mac.Sum(nil), encoding)
+	if err != nil {
+		return "", invalidInput("HMAC", err)
+	}
+	return out, nil
+}
+
+func encodeDigest(sum []byte, encoding string) (string, error) {
+	switch strings.ToLower(encoding) {
+	case "", "hex":
+		return hex.EncodeToString(sum), nil
+	case "base64":
+		return base64.StdEncoding.EncodeToString(sum), nil
+	case "base64url":
+		return base64.RawURLEncoding.EncodeToString(sum), nil
+	default:
+		return "", fmt.Errorf("unsupported encoding %q", encoding)
+	}
+}
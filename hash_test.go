@@ -0,0 +1,135 @@
+package filter
+
+import (
+	"crypto/hmac"
+	"encoding/base64"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHashFunctions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		hash  func(string) string
+		input string
+		want  string
+	}{
+		{"MD5", MD5, "abc", "900150983cd24fb0d6963f7d28e17f72"},
+		{"MD5 Empty", MD5, "", "d41d8cd98f00b204e9800998ecf8427e"},
+		{"SHA1", SHA1, "abc", "a9993e364706816aba3e25717850c26c9cd0d89d"},
+		{"SHA256", SHA256, "abc", "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"},
+		{"SHA256 Empty", SHA256, "", "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"},
+		{"SHA512", SHA512, "abc", "ddaf35a193617abacc417349ae20413112e6fa4e89a97ea20a9eeee64b55d39a2192992a274fc1a836ba3c23a3feebbd454d4423643ce80e2a9ac94fa54ca49f"},
+		{"CRC32", CRC32, "123456789", "cbf43926"},
+		{"CRC32 Leading Zeros", CRC32, "", "00000000"},
+		{"XXHash", XXHash, "abc", "44bc2cf5ad770999"},
+		{"XXHash Empty", XXHash, "", "ef46db3751d8e999"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tt.want, tt.hash(tt.input))
+		})
+	}
+}
+
+func TestHash(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		algorithm string
+		encoding  string
+		want      string
+	}{
+		{"SHA256 Hex", "sha256", "hex", SHA256("abc")},
+		{"Default Encoding Is Hex", "md5", "", MD5("abc")},
+		{"Names Are Case Insensitive", "SHA1", "HEX", SHA1("abc")},
+		{"SHA256 Base64", "sha256", "base64", "ungWv48Bz+pBQUDeXa4iI7ADYaOWF3qctBD/YfIAFa0="},
+		{"SHA256 Base64URL", "sha256", "base64url", "ungWv48Bz-pBQUDeXa4iI7ADYaOWF3qctBD_YfIAFa0"},
+		{"SHA512 Hex", "sha512", "hex", SHA512("abc")},
+		{"CRC32 Hex", "crc32", "hex", CRC32("abc")},
+		{"XXHash Hex", "xxhash", "hex", XXHash("abc")},
+		{"XXHash64 Alias", "xxhash64", "hex", XXHash("abc")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := Hash("abc", tt.algorithm, tt.encoding)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestHashInvalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := Hash("abc", "sha3", "hex")
+	require.ErrorIs(t, err, ErrInvalidInput)
+
+	_, err = Hash("abc", "sha256", "base32")
+	require.ErrorIs(t, err, ErrInvalidInput)
+}
+
+func TestHMAC(t *testing.T) {
+	t.Parallel()
+
+	// Test vectors from RFC 2104, RFC 2202, and RFC 4231 (test case 2).
+	const key, data = "Jefe", "what do ya want for nothing?"
+	tests := []struct {
+		algorithm string
+		want      string
+	}{
+		{"md5", "750c783e6ab0b503eaa86e310a5db738"},
+		{"sha1", "effcdf6ae5eb2fa2d27416d5f184df9c259a7c79"},
+		{"sha256", "5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843"},
+		{"sha512", "164b7a7bfcf819e2e395fbe73b56e0a387bd64222e831fd610270cd7ea2505549758bf75c05a994a6d034f65f8f0e6fdcaeab1a34d4a6b4b636e070a38bce737"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.algorithm, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := HMAC(data, key, tt.algorithm, "hex")
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestHMACEncodings(t *testing.T) {
+	t.Parallel()
+
+	hexSig, err := HMAC("/download/report.pdf?expires=1700000000", "secret", "sha256", "hex")
+	require.NoError(t, err)
+	raw, err := hex.DecodeString(hexSig)
+	require.NoError(t, err)
+
+	urlSig, err := HMAC("/download/report.pdf?expires=1700000000", "secret", "sha256", "base64url")
+	require.NoError(t, err)
+	require.Equal(t, base64.RawURLEncoding.EncodeToString(raw), urlSig)
+
+	other, err := HMAC("/download/report.pdf?expires=1700000000", "other", "sha256", "hex")
+	require.NoError(t, err)
+	require.False(t, hmac.Equal([]byte(hexSig), []byte(other)))
+}
+
+func TestHMACInvalid(t *testing.T) {
+	t.Parallel()
+
+	for _, algorithm := range []string{"crc32", "xxhash", "sha3", ""} {
+		_, err := HMAC("data", "key", algorithm, "hex")
+		require.ErrorIs(t, err, ErrInvalidInput, "algorithm %q", algorithm)
+	}
+
+	_, err := HMAC("data", "key", "sha256", "binary")
+	require.ErrorIs(t, err, ErrInvalidInput)
+}
@@ -0,0 +1,311 @@
+package filter
+
+import (
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// HTMLToText converts an HTML document or fragment into readable plain
+// text, such as the text part of an email:
+//
+//   - Paragraphs, headings, block quotes, tables, and top-level lists are
+//     separated by a blank line; other block elements such as div, li, and
+//     tr, as well as br, start a new line.
+//   - List items become "- " bullets, or "1. ", "2. ", ... in ordered
+//     lists. Nested lists are indented by two spaces per level.
+//   - Links become "text (url)". The URL is left out when it equals the
+//     text or is a fragment or javascript: link; a link without text
+//     becomes its URL. Images become their alt text.
+//   - Character references are decoded, and runs of whitespace collapse to
+//     one space except inside pre. Non-breaking spaces become spaces.
+//   - The contents of script, style, title, template, and similar elements
+//     are dropped.
+//
+// Lines are separated by "\n" and the result has no leading or trailing
+// line breaks. Like StripHTML it is a formatting helper, not a sanitizer.
+func HTMLToText(input string) string {
+	w := &htmlTextWriter{}
+	z := html.NewTokenizer(strings.NewReader(input))
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			return w.b.String()
+		case html.TextToken:
+			if w.skip == 0 {
+				w.text(string(z.Text()))
+			}
+		case html.StartTagToken:
+			w.start(z.Token(), false)
+		case html.SelfClosingTagToken:
+			w.start(z.Token(), true)
+		case html.EndTagToken:
+			w.end(z.Token())
+		}
+	}
+}
+
+// htmlSkipped lists elements whose contents are not visible text.
+var htmlSkipped = map[atom.Atom]bool{
+	atom.Script: true, atom.Style: true, atom.Title: true, atom.Template: true,
+	atom.Noscript: true, atom.Iframe: true, atom.Noembed: true, atom.Noframes: true,
+	atom.Svg: true, atom.Select: true,
+}
+
+// htmlBreaks maps block elements to the number of line breaks that
+// separate them from surrounding text: 2 for a blank line, 1 for a new line.
+var htmlBreaks = map[atom.Atom]int{
+	atom.P: 2, atom.H1: 2, atom.H2: 2, atom.H3: 2, atom.H4: 2, atom.H5: 2,
+	atom.H6: 2, atom.Blockquote: 2, atom.Pre: 2, atom.Table: 2, atom.Hr: 2,
+	atom.Dl: 2, atom.Figure: 2,
+
+	atom.Div: 1, atom.Li: 1, atom.Tr: 1, atom.Dt: 1, atom.Dd: 1,
+	atom.Section: 1, atom.Article: 1, atom.Header: 1, atom.Footer: 1,
+	atom.Nav: 1, atom.Aside: 1, atom.Main: 1, atom.Address: 1, atom.Form: 1,
+	atom.Fieldset: 1, atom.Legend: 1, atom.Figcaption: 1, atom.Caption: 1,
+	atom.Details: 1, atom.Summary: 1, atom.Br: 1,
+}
+
+type htmlTextList struct {
+	ordered bool
+	next    int    // number of the next item in an ordered list
+	margin  string // indent for continuation lines of the current item
+}
+
+type htmlTextLink struct {
+	href  string
+	start int // offset of the link's first text in the output, or -1
+}
+
+// htmlTextWriter accumulates output. Line breaks and spaces are held as
+// pending until the next visible text, so the result never starts or ends
+// with whitespace and consecutive blocks do not stack blank lines.
+type htmlTextWriter struct {
+	b        strings.Builder
+	newlines int  // pending line breaks
+	space    bool // pending space
+	skip     int  // depth inside htmlSkipped elements
+	pre      int  // depth inside pre elements
+	preStart bool // the next text directly follows <pre>
+	lists    []htmlTextList
+	bullet   string // marker for the next line in a list item
+	links    []htmlTextLink
+}
+
+func (w *htmlTextWriter) start(t html.Token, selfClosing bool) {
+	if htmlSkipped[t.DataAtom] {
+		if !selfClosing {
+			w.skip++
+		}
+		return
+	}
+	if w.skip > 0 {
+		return
+	}
+	switch t.DataAtom {
+	case atom.Ul, atom.Ol:
+		w.lineBreak(w.listBreaks())
+		list := htmlTextList{ordered: t.DataAtom == atom.Ol, next: 1}
+		if n, err := strconv.Atoi(htmlAttr(t, "start")); err == nil {
+			list.next = n
+		}
+		w.lists = append(w.lists, list)
+		return
+	case atom.Li:
+		w.lineBreak(1)
+		w.bullet = w.listMarker()
+		return
+	case atom.A:
+		w.links = append(w.links, htmlTextLink{href: strings.TrimSpace(htmlAttr(t, "href")), start: -1})
+		return
+	case atom.Img:
+		w.text(htmlAttr(t, "alt"))
+		return
+	case atom.Td, atom.Th:
+		w.space = true
+		return
+	case atom.Pre:
+		w.pre++
+		w.preStart = true
+	}
+	if n := htmlBreaks[t.DataAtom]; n > 0 {
+		w.lineBreak(n)
+	}
+}
+
+func (w *htmlTextWriter) end(t html.Token) {
+	if htmlSkipped[t.DataAtom] {
+		w.skip = max(w.skip-1, 0)
+		return
+	}
+	if w.skip > 0 {
+		return
+	}
+	switch t.DataAtom {
+	case atom.Ul, atom.Ol:
+		if len(w.lists) > 0 {
+			w.lists = w.lists[:len(w.lists)-1]
+		}
+		w.bullet = ""
+		w.lineBreak(w.listBreaks())
+		return
+	case atom.A:
+		w.endLink()
+		return
+	case atom.Td, atom.Th:
+		w.space = true
+		return
+	case atom.Pre:
+		w.pre = max(w.pre-1, 0)
+	}
+	if n := htmlBreaks[t.DataAtom]; n > 0 {
+		w.lineBreak(n)
+	}
+}
+
+// listBreaks separates a top-level list from its surroundings by a blank
+// line and a nested list by a line break.
+func (w *htmlTextWriter) listBreaks() int {
+	if len(w.lists) > 0 {
+		return 1
+	}
+	return 2
+}
+
+// listMarker returns the bullet for a new item of the innermost list and
+// records the indent that aligns the item's later lines with its text. An
+// item outside any list gets an unordered bullet and no indent, since no
+// closing list tag would end one.
+func (w *htmlTextWriter) listMarker() string {
+	if len(w.lists) == 0 {
+		return "- "
+	}
+	list := &w.lists[len(w.lists)-1]
+	indent := strings.Repeat("  ", len(w.lists)-1)
+	marker := "- "
+	if list.ordered {
+		marker = strconv.Itoa(list.next) + ". "
+		list.next++
+	}
+	list.margin = indent + strings.Repeat(" ", len(marker))
+	return indent + marker
+}
+
+// margin returns the indent for a new line that does not start a list
+// item.
+func (w *htmlTextWriter) margin() string {
+	if len(w.lists) == 0 {
+		return ""
+	}
+	return w.lists[len(w.lists)-1].margin
+}
+
+func (w *htmlTextWriter) endLink() {
+	if len(w.links) == 0 {
+		return
+	}
+	link := w.links[len(w.links)-1]
+	w.links = w.links[:len(w.links)-1]
+	href := link.href
+	lower := strings.ToLower(href)
+	if href == "" || strings.HasPrefix(href, "#") || strings.HasPrefix(lower, "javascript:") {
+		return
+	}
+	text := ""
+	if link.start >= 0 {
+		text = strings.TrimSpace(w.b.String()[link.start:])
+	}
+	switch {
+	case text == "":
+		w.emit(href)
+	case text != href && "mailto:"+text != href:
+		w.space = true
+		w.emit("(" + href + ")")
+	}
+}
+
+func (w *htmlTextWriter) lineBreak(n int) {
+	w.newlines = max(w.newlines, n)
+	w.space = false
+}
+
+// text writes a text token, collapsing whitespace outside pre.
+// Non-breaking spaces become spaces but do not collapse.
+func (w *htmlTextWriter) text(s string) {
+	if w.pre > 0 {
+		s = strings.ReplaceAll(s, "\u00a0", " ")
+		if w.preStart {
+			s = strings.TrimPrefix(s, "\n")
+		}
+		w.preStart = false
+		for i, line := range strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n") {
+			if i > 0 {
+				w.newlines++
+			}
+			w.emit(line)
+		}
+		return
+	}
+	words := strings.FieldsFunc(s, isHTMLSpace)
+	if len(words) == 0 {
+		if s != "" {
+			w.space = true
+		}
+		return
+	}
+	if isHTMLSpace(rune(s[0])) {
+		w.space = true
+	}
+	for i, word := range words {
+		if i > 0 {
+			w.space = true
+		}
+		w.emit(strings.ReplaceAll(word, "\u00a0", " "))
+	}
+	if isHTMLSpace(rune(s[len(s)-1])) {
+		w.space = true
+	}
+}
+
+// emit writes s after any pending line breaks or space. Pending breaks
+// before the first text are dropped.
+func (w *htmlTextWriter) emit(s string) {
+	if s == "" {
+		return
+	}
+	if w.b.Len() == 0 || w.newlines > 0 {
+		if w.b.Len() > 0 {
+			w.b.WriteString(strings.Repeat("\n", w.newlines))
+		}
+		if w.bullet != "" {
+			w.b.WriteString(w.bullet)
+			w.bullet = ""
+		} else {
+			w.b.WriteString(w.margin())
+		}
+	} else if w.space {
+		w.b.WriteByte(' ')
+	}
+	w.newlines, w.space = 0, false
+	for i := range w.links {
+		if w.links[i].start < 0 {
+			w.links[i].start = w.b.Len()
+		}
+	}
+	w.b.WriteString(s)
+}
+
+func isHTMLSpace(r rune) bool {
+	return r == ' ' || r == '\t' || r == '\n' || r == '\f' || r == '\r'
+}
+
+func htmlAttr(t html.Token, name string) string {
+	for _, a := range t.Attr {
+		if a.Key == name {
+			return a.Val
+		}
+	}
+	return ""
+}
@@ -0,0 +1,88 @@
+package filter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTMLToText(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"Empty", "", ""},
+		{"Plain Text", "Hello world", "Hello world"},
+		{"Paragraphs", "<p>a</p><p>b</p>", "a\n\nb"},
+		{"Div And Br", "<div>one</div><div>two<br>three</div>", "one\ntwo\nthree"},
+		{"Heading Then Paragraph", "<h1>Title</h1><p>Body</p>", "Title\n\nBody"},
+		{"Collapse Whitespace", "<p>  Hello \n\t  <b>big</b>\n world  </p>", "Hello big world"},
+		{"Inline Without Space", "un<em>believ</em>able", "unbelievable"},
+		{"Entities", "<p>Tom &amp; Jerry &lt;3 &quot;cheese&quot; &#8212; &eacute;</p>", "Tom & Jerry <3 \"cheese\" — é"},
+		{"Non-Breaking Space", "a&nbsp;&nbsp;b", "a  b"},
+		{"Unordered List", "<p>Items:</p><ul><li>One</li><li>Two</li></ul><p>Done</p>", "Items:\n\n- One\n- Two\n\nDone"},
+		{"Ordered List", "<ol><li>First</li><li>Second</li></ol>", "1. First\n2. Second"},
+		{"Ordered List Start", `<ol start="9"><li>Nine</li><li>Ten</li></ol>`, "9. Nine\n10. Ten"},
+		{
+			"Nested List",
+			"<ul><li>Fruit<ul><li>Apple</li><li>Pear</li></ul></li><li>Veg</li></ul>",
+			"- Fruit\n  - Apple\n  - Pear\n- Veg",
+		},
+		{"List Item Continuation", "<ol><li>Step<br>more detail</li></ol>", "1. Step\n   more detail"},
+		{"Unclosed List Items", "<ul><li>a<li>b</ul>", "- a\n- b"},
+		{"Orphan List Item", "<li>a</li><p>b</p>", "- a\n\nb"},
+		{"Orphan List Items", "<li>a</li><li>b</li>", "- a\n- b"},
+		{"Link", `<a href="https://example.com">Example</a>`, "Example (https://example.com)"},
+		{"Link Same As Text", `<a href="https://example.com">https://example.com</a>`, "https://example.com"},
+		{"Mailto Link", `<a href="mailto:me@example.com">me@example.com</a>`, "me@example.com"},
+		{"Link Without Text", `See <a href="https://example.com"></a>`, "See https://example.com"},
+		{"Fragment Link", `<a href="#top">Back to top</a>`, "Back to top"},
+		{"JavaScript Link", `<a href="JavaScript:void(0)">Click</a>`, "Click"},
+		{"Link In List", `<ul><li><a href="/a">A</a></li></ul>`, "- A (/a)"},
+		{"Image Alt", `<p>Logo: <img src="x.png" alt="ACME"></p>`, "Logo: ACME"},
+		{"Script And Style Dropped", "<style>p{}</style><p>Hi</p><script>alert('<p>x</p>')</script>", "Hi"},
+		{"Head Dropped", "<html><head><title>T</title><meta charset=utf-8></head><body><p>Body</p></body></html>", "Body"},
+		{"Comment Dropped", "a<!-- hidden -->b", "ab"},
+		{"Pre Keeps Whitespace", "<p>Code:</p><pre>\nfunc main() {\n\tx  :=  1\n}\n</pre><p>End</p>", "Code:\n\nfunc main() {\n\tx  :=  1\n}\n\nEnd"},
+		{"Table", "<table><tr><th>Name</th><th>Age</th></tr><tr><td>Ann</td><td>30</td></tr></table>", "Name Age\nAnn 30"},
+		{"Blockquote", "<p>He said:</p><blockquote>Hello</blockquote>", "He said:\n\nHello"},
+		{"Leading And Trailing Breaks Dropped", "<br><p>x</p><br>", "x"},
+		{"Stray End Tags", "</ul></a></pre>text", "text"},
+		{"Malformed", "<p>a <b>b</p> c", "a b\n\nc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tt.want, HTMLToText(tt.input))
+		})
+	}
+}
+
+func TestHTMLToTextLeavesStripHTMLUnchanged(t *testing.T) {
+	t.Parallel()
+
+	input := "<p>a</p><p>b &amp; c</p>"
+	require.Equal(t, "ab &amp; c", StripHTML(input))
+	require.Equal(t, "a\n\nb & c", HTMLToText(input))
+}
+
+func FuzzHTMLToText(f *testing.F) {
+	f.Add("<p>a</p><ul><li>b<ol><li>c</li></ol></li></ul>")
+	f.Add(`<a href="u">t</a><pre> x </pre>`)
+	f.Add("<script>x</script>&amp;")
+	f.Fuzz(func(t *testing.T, input string) {
+		got := HTMLToText(input)
+		if got != strings.Trim(got, "\n") {
+			t.Fatalf("HTMLToText(%q) = %q has surrounding line breaks", input, got)
+		}
+		if strings.Contains(got, "\n\n\n") && !strings.Contains(input, "pre") {
+			t.Fatalf("HTMLToText(%q) = %q stacks blank lines", input, got)
+		}
+	})
+}
@@ -0,0 +1,80 @@
+package filter
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// JSONOptions configures ToJSONWithOptions. The zero value behaves exactly
+// like ToJSON.
+type JSONOptions struct {
+	// Indent, when not empty, pretty-prints the output with one element per
+	// line, each nesting level indented by Indent, such as "  " or "\t".
+	Indent string
+	// EscapeHTML writes <, >, and & inside strings as \u003c, \u003e, and
+	// \u0026, so the output can be embedded in an HTML <script> element
+	// without closing it early. For an attribute value, also pass the
+	// result through Escape.
+	EscapeHTML bool
+}
+
+// ToJSON encodes input as compact JSON with encoding/json rules: struct
+// fields follow their json tags, map keys are sorted, and []byte becomes
+// Base64. The output is stable for equal input. <, >, and & are kept as
+// is; use ToJSONWithOptions with EscapeHTML to embed the result in HTML.
+//
+// A value JSON cannot represent, such as a channel, a function, or NaN,
+// returns *Error{Kind: KindInvalidInput}.
+func ToJSON(input any) (string, error) {
+	return toJSON("ToJSON", input, JSONOptions{})
+}
+
+// ToJSONWithOptions is ToJSON with indentation and HTML escaping selected
+// by opts.
+func ToJSONWithOptions(input any, opts JSONOptions) (string, error) {
+	return toJSON("ToJSONWithOptions", input, opts)
+}
+
+func toJSON(op string, input any, opts JSONOptions) (string, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(opts.EscapeHTML)
+	enc.SetIndent("", opts.Indent)
+	if err := enc.Encode(input); err != nil {
+		return "", invalidInput(op, err)
+	}
+	// Encode ends every value with a newline.
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// ParseJSON decodes a single JSON value. Objects become map[string]any,
+// arrays []any, strings string, booleans bool, and null nil. Numbers become
+// json.Number so no precision is lost; the numeric, comparison, and date
+// filters accept it like any other number, and its Int64, Float64, and
+// String methods give the value directly.
+//
+// Invalid JSON, including trailing data after the value, returns
+// *Error{Kind: KindFormat} whose cause gives the byte offset of the error
+// and wraps the *json.SyntaxError when there is one.
+func ParseJSON(input string) (any, error) {
+	// Unmarshal validates the whole input first, so syntax errors carry the
+	// offset into input and trailing data is rejected.
+	var raw json.RawMessage
+	if err := json.Unmarshal([]byte(input), &raw); err != nil {
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			err = fmt.Errorf("at byte offset %d: %w", syntaxErr.Offset, syntaxErr)
+		}
+		return nil, formatErr("ParseJSON", err)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var out any
+	// raw is valid JSON, so decoding it cannot fail.
+	_ = dec.Decode(&out)
+	return out, nil
+}
@@ -0,0 +1,220 @@
+package filter
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestToJSON(t *testing.T) {
+	t.Parallel()
+
+	type address struct {
+		City string `json:"city"`
+		Zip  string `json:"zip,omitempty"`
+	}
+	type user struct {
+		Name    string   `json:"name"`
+		Tags    []string `json:"tags"`
+		Address address  `json:"address"`
+		secret  string
+	}
+
+	tests := []struct {
+		name  string
+		input any
+		want  string
+	}{
+		{"Nil", nil, "null"},
+		{"String", "hi", `"hi"`},
+		{"Number", 3.5, "3.5"},
+		{"Sorted Map Keys", map[string]any{"b": 2, "a": 1, "c": []any{true, nil}}, `{"a":1,"b":2,"c":[true,null]}`},
+		{"Int Map Keys Sorted As Strings", map[int]string{10: "x", 2: "y"}, `{"10":"x","2":"y"}`},
+		{"Struct Tags", user{Name: "Ada", Tags: []string{"x"}, Address: address{City: "Paris"}, secret: "s"}, `{"name":"Ada","tags":["x"],"address":{"city":"Paris"}}`},
+		{"HTML Kept", "</script>&", `"</script>&"`},
+		{"Line Separators Escaped", "a\u2028b", `"a\u2028b"`},
+		{"JSON Number", json.Number("12345678901234567890"), "12345678901234567890"},
+		{"Bytes As Base64", []byte("hi"), `"aGk="`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ToJSON(tt.input)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestToJSONWithOptions(t *testing.T) {
+	t.Parallel()
+
+	input := map[string]any{"b": []any{1, 2}, "a": "<b>&</b>", "c": map[string]any{}}
+
+	got, err := ToJSONWithOptions(input, JSONOptions{})
+	require.NoError(t, err)
+	want, err := ToJSON(input)
+	require.NoError(t, err)
+	require.Equal(t, want, got)
+
+	got, err = ToJSONWithOptions(input, JSONOptions{Indent: "  "})
+	require.NoError(t, err)
+	require.Equal(t, "{\n  \"a\": \"<b>&</b>\",\n  \"b\": [\n    1,\n    2\n  ],\n  \"c\": {}\n}", got)
+
+	got, err = ToJSONWithOptions(input, JSONOptions{EscapeHTML: true})
+	require.NoError(t, err)
+	require.Equal(t, `{"a":"\u003cb\u003e\u0026\u003c/b\u003e","b":[1,2],"c":{}}`, got)
+}
+
+func TestToJSONUnsupported(t *testing.T) {
+	t.Parallel()
+
+	for _, input := range []any{make(chan int), func() {}, math.NaN(), math.Inf(1), map[bool]int{true: 1}} {
+		_, err := ToJSON(input)
+		require.ErrorIs(t, err, ErrInvalidInput, "input %#v", input)
+	}
+}
+
+func TestParseJSON(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input string
+		want  any
+	}{
+		{"Object", `{"a": 1, "b": [true, null, "x"]}`, map[string]any{"a": json.Number("1"), "b": []any{true, nil, "x"}}},
+		{"Array", `[1.5, -2e3]`, []any{json.Number("1.5"), json.Number("-2e3")}},
+		{"Big Integer Exact", `12345678901234567890`, json.Number("12345678901234567890")},
+		{"String", `"café"`, "café"},
+		{"Null", `null`, nil},
+		{"Surrounding Whitespace", " \n{}\t", map[string]any{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseJSON(tt.input)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseJSONNumbersWithFilters(t *testing.T) {
+	t.Parallel()
+
+	parsed, err := ParseJSON(`{"items": [{"n": 5, "price": 2.5}, {"n": 7, "price": 1}], "ts": 1711811045, "big": 12345678901234567890}`)
+	require.NoError(t, err)
+	data := parsed.(map[string]any)
+	items := data["items"]
+
+	matched, err := Where(items, "n", 5)
+	require.NoError(t, err)
+	require.Len(t, matched, 1)
+
+	total, err := SumBy(items, "price")
+	require.NoError(t, err)
+	require.InDelta(t, 3.5, total, 1e-9)
+
+	sorted, err := Sort(items, "price")
+	require.NoError(t, err)
+	require.Equal(t, json.Number("1"), sorted[0].(map[string]any)["price"])
+
+	sum, err := Plus(data["ts"], 1)
+	require.NoError(t, err)
+	require.InDelta(t, 1711811046.0, sum, 1e-9)
+
+	date, err := Date(data["ts"], "Y-m-d")
+	require.NoError(t, err)
+	require.Equal(t, "2024-03-30", date)
+
+	formatted, err := Number(data["big"], "#,###")
+	require.NoError(t, err)
+	require.Equal(t, "12,345,678,901,234,567,890", formatted)
+
+	n, err := toInt64Exact("test", data["ts"])
+	require.NoError(t, err)
+	require.Equal(t, int64(1711811045), n)
+
+	_, err = toInt64Exact("test", data["big"])
+	require.ErrorIs(t, err, ErrInvalidInput)
+}
+
+func TestToJSONErrorOp(t *testing.T) {
+	t.Parallel()
+
+	var filterErr *Error
+	_, err := ToJSON(make(chan int))
+	require.ErrorAs(t, err, &filterErr)
+	require.Equal(t, "ToJSON", filterErr.Op)
+
+	_, err = ToJSONWithOptions(make(chan int), JSONOptions{Indent: "  "})
+	require.ErrorAs(t, err, &filterErr)
+	require.Equal(t, "ToJSONWithOptions", filterErr.Op)
+}
+
+func TestParseJSONSyntaxError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		input  string
+		offset int64
+	}{
+		{"Trailing Comma", `{"a":1,}`, 8},
+		{"Bad Literal", `[tru]`, 5},
+		{"Trailing Data", `1 2`, 3},
+		{"Truncated", `{"a":`, 5},
+		{"Empty", ``, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := ParseJSON(tt.input)
+			require.ErrorIs(t, err, ErrFormat)
+			var syntaxErr *json.SyntaxError
+			require.ErrorAs(t, err, &syntaxErr)
+			require.Equal(t, tt.offset, syntaxErr.Offset)
+			require.ErrorContains(t, err, "byte offset")
+		})
+	}
+}
+
+func FuzzParseJSON(f *testing.F) {
+	f.Add(`{"a":[1,2.5,"x",null,true]}`)
+	f.Add(`12345678901234567890`)
+	f.Add(`{"a":1,}`)
+	f.Add(`" <>&"`)
+	f.Fuzz(func(t *testing.T, input string) {
+		v, err := ParseJSON(input)
+		if valid := json.Valid([]byte(input)); valid != (err == nil) {
+			t.Fatalf("ParseJSON(%q) error %v, but json.Valid = %v", input, err, valid)
+		}
+		if err != nil {
+			if !errors.Is(err, ErrFormat) {
+				t.Fatalf("ParseJSON(%q) error %v is not KindFormat", input, err)
+			}
+			return
+		}
+		out, err := ToJSON(v)
+		if err != nil {
+			t.Fatalf("ToJSON(ParseJSON(%q)): %v", input, err)
+		}
+		again, err := ParseJSON(out)
+		if err != nil {
+			t.Fatalf("ParseJSON(%q) from %q: %v", out, input, err)
+		}
+		if out2, _ := ToJSON(again); out2 != out {
+			t.Fatalf("ToJSON is not stable for %q: %q then %q", input, out, out2)
+		}
+	})
+}
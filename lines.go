@@ -0,0 +1,148 @@
+package filter
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Lines splits input into lines at every line break StripNewlines removes:
+// "\r\n", "\r", and "\n". The breaks are not included. A single trailing
+// line break does not produce a final empty line, and empty input yields an
+// empty slice.
+func Lines(input string) []string {
+	if input == "" {
+		return []string{}
+	}
+	lines := splitLines(input)
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// Indent prepends prefix to every line of input that is not blank, keeping
+// the input's line breaks. Whitespace-only lines are left untouched so no
+// trailing whitespace is introduced. With skipFirst the first line is left
+// as is, for values placed after a key on the same line.
+func Indent(input, prefix string, skipFirst bool) string {
+	var b strings.Builder
+	b.Grow(len(input) + len(prefix)*strings.Count(input, "\n"))
+	for i, line := range splitLinesAfter(input) {
+		if !(skipFirst && i == 0) && strings.TrimSpace(line) != "" {
+			b.WriteString(prefix)
+		}
+		b.WriteString(line)
+	}
+	return b.String()
+}
+
+// Dedent removes the longest run of leading spaces and tabs common to every
+// non-blank line of input, keeping the input's line breaks. Whitespace-only
+// lines do not take part and are reduced to their line break. Tabs and
+// spaces are not interchangeable: "\t" and "    " share no common prefix.
+func Dedent(input string) string {
+	lines := splitLinesAfter(input)
+	margin, found := "", false
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		if !found {
+			margin, found = indent, true
+			continue
+		}
+		margin = commonPrefix(margin, indent)
+	}
+
+	var b strings.Builder
+	b.Grow(len(input))
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			b.WriteString(line[len(strings.TrimRight(line, "\r\n")):])
+			continue
+		}
+		b.WriteString(line[len(margin):])
+	}
+	return b.String()
+}
+
+// NumberLines prefixes each line of input with its line number, right
+// aligned to the widest number and followed by ": ", keeping the input's
+// line breaks. Numbering starts at start (default 1). A trailing line break
+// does not add a numbered empty line, matching Lines.
+func NumberLines(input string, start ...int) string {
+	if input == "" {
+		return ""
+	}
+	first := 1
+	if len(start) > 0 {
+		first = start[0]
+	}
+	lines := splitLinesAfter(input)
+	width := max(len(strconv.Itoa(first)), len(strconv.Itoa(first+len(lines)-1)))
+
+	var b strings.Builder
+	b.Grow(len(input) + len(lines)*(width+2))
+	for i, line := range lines {
+		number := strconv.Itoa(first + i)
+		b.WriteString(strings.Repeat(" ", width-len(number)))
+		b.WriteString(number)
+		b.WriteString(": ")
+		b.WriteString(line)
+	}
+	return b.String()
+}
+
+// NewlineToBR HTML-escapes input and then inserts "<br />" before every line
+// break, normalizing each break to "\n". Escaping first means markup in the
+// input is shown as text rather than interpreted.
+func NewlineToBR(input string) string {
+	lines := splitLines(Escape(input))
+	return strings.Join(lines, "<br />\n")
+}
+
+// splitLines splits s at every line break recognized by newlineReplacer:
+// "\r\n", "\r", and "\n". A trailing line break yields a final empty line.
+func splitLines(s string) []string {
+	lines := make([]string, 0, strings.Count(s, "\n")+1)
+	for {
+		i := strings.IndexAny(s, "\r\n")
+		if i < 0 {
+			return append(lines, s)
+		}
+		lines = append(lines, s[:i])
+		if s[i] == '\r' && i+1 < len(s) && s[i+1] == '\n' {
+			i++
+		}
+		s = s[i+1:]
+	}
+}
+
+// splitLinesAfter is splitLines with each line keeping its line break. A
+// trailing line break does not yield a final empty line.
+func splitLinesAfter(s string) []string {
+	lines := make([]string, 0, strings.Count(s, "\n")+1)
+	for s != "" {
+		i := strings.IndexAny(s, "\r\n")
+		if i < 0 {
+			return append(lines, s)
+		}
+		if s[i] == '\r' && i+1 < len(s) && s[i+1] == '\n' {
+			i++
+		}
+		lines = append(lines, s[:i+1])
+		s = s[i+1:]
+	}
+	return lines
+}
+
+func commonPrefix(a, b string) string {
+	n := min(len(a), len(b))
+	for i := range n {
+		if a[i] != b[i] {
+			return a[:i]
+		}
+	}
+	return a[:n]
+}
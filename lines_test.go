@@ -0,0 +1,155 @@
+package filter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLines(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{"Empty", "", []string{}},
+		{"Single Line", "hello", []string{"hello"}},
+		{"LF", "a\nb", []string{"a", "b"}},
+		{"CRLF", "a\r\nb", []string{"a", "b"}},
+		{"CR", "a\rb", []string{"a", "b"}},
+		{"Mixed", "a\r\nb\rc\nd", []string{"a", "b", "c", "d"}},
+		{"LFCR Is Two Breaks", "a\n\rb", []string{"a", "", "b"}},
+		{"Trailing Break Dropped", "a\nb\n", []string{"a", "b"}},
+		{"Only Last Trailing Break Dropped", "a\n\n", []string{"a", ""}},
+		{"Blank Lines Kept", "a\n\n\nb", []string{"a", "", "", "b"}},
+		{"Single Break", "\n", []string{""}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tt.want, Lines(tt.input))
+		})
+	}
+}
+
+func TestLinesAgreesWithStripNewlines(t *testing.T) {
+	t.Parallel()
+
+	for _, input := range []string{"a\r\nb\rc\nd", "\r\r\n\n", "no breaks", "x\n\ry\r\n"} {
+		require.Equal(t, StripNewlines(input), strings.Join(Lines(input), ""), "input %q", input)
+	}
+}
+
+func TestIndent(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		input     string
+		prefix    string
+		skipFirst bool
+		want      string
+	}{
+		{"Empty", "", "  ", false, ""},
+		{"Every Line", "a\nb\nc", "  ", false, "  a\n  b\n  c"},
+		{"Skip First", "key: |\nline one\nline two", "  ", true, "key: |\n  line one\n  line two"},
+		{"Blank Lines Untouched", "a\n\n   \nb", "> ", false, "> a\n\n   \n> b"},
+		{"Keeps Line Breaks", "a\r\nb\rc\n", "\t", false, "\ta\r\n\tb\r\tc\n"},
+		{"Empty Prefix", "a\nb", "", false, "a\nb"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tt.want, Indent(tt.input, tt.prefix, tt.skipFirst))
+		})
+	}
+}
+
+func TestDedent(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"Empty", "", ""},
+		{"Common Spaces", "    a\n      b\n    c", "a\n  b\nc"},
+		{"Blank Lines Ignored", "    a\n\n  \n    b\n", "a\n\n\nb\n"},
+		{"No Common Indent", "a\n  b", "a\n  b"},
+		{"Tabs", "\t\tif x {\n\t\t\ty()\n\t\t}", "if x {\n\ty()\n}"},
+		{"Mixed Tabs And Spaces", "\ta\n    b", "\ta\n    b"},
+		{"Shared Mixed Prefix", " \ta\n \t b", "a\n b"},
+		{"Keeps Line Breaks", "  a\r\n  b\r  c", "a\r\nb\rc"},
+		{"Only Whitespace", "   \n  ", "\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tt.want, Dedent(tt.input))
+		})
+	}
+}
+
+func TestIndentDedentRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	input := "func main() {\n\tfmt.Println(1)\n\n}\n"
+	require.Equal(t, input, Dedent(Indent(input, "    ", false)))
+}
+
+func TestNumberLines(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input string
+		start []int
+		want  string
+	}{
+		{"Empty", "", nil, ""},
+		{"Default Start", "a\nb", nil, "1: a\n2: b"},
+		{"Trailing Break", "a\nb\n", nil, "1: a\n2: b\n"},
+		{"Aligns Numbers", strings.Repeat("x\n", 10), nil, " 1: x\n 2: x\n 3: x\n 4: x\n 5: x\n 6: x\n 7: x\n 8: x\n 9: x\n10: x\n"},
+		{"Custom Start", "a\nb", []int{99}, " 99: a\n100: b"},
+		{"Blank Lines Numbered", "a\n\nb", nil, "1: a\n2: \n3: b"},
+		{"Keeps Line Breaks", "a\r\nb\rc", nil, "1: a\r\n2: b\r3: c"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tt.want, NumberLines(tt.input, tt.start...))
+		})
+	}
+}
+
+func TestNewlineToBR(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"", ""},
+		{"no breaks", "no breaks"},
+		{"a\nb", "a<br />\nb"},
+		{"a\r\nb\rc", "a<br />\nb<br />\nc"},
+		{"<b>bold</b>\n& more", "&lt;b&gt;bold&lt;/b&gt;<br />\n&amp; more"},
+		{"trailing\n", "trailing<br />\n"},
+	}
+
+	for _, tt := range tests {
+		require.Equal(t, tt.want, NewlineToBR(tt.input), "NewlineToBR(%q)", tt.input)
+	}
+}
@@ -0,0 +1,229 @@
+package filter
+
+import (
+	"bufio"
+	"bytes"
+	"html"
+	"strings"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/renderer"
+	gmhtml "github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// MarkdownOptions configures MarkdownWithOptions. The zero value behaves
+// exactly like Markdown.
+type MarkdownOptions struct {
+	// RawHTML passes raw HTML in the input through to the output, and
+	// keeps link and image URLs with any scheme, including javascript:.
+	// Only use it for trusted input, or pass the result to SanitizeHTML.
+	RawHTML bool
+	// HardWraps renders every line break inside a paragraph as <br />
+	// instead of a space.
+	HardWraps bool
+}
+
+// Markdown renders input as CommonMark to HTML: headings, emphasis, lists,
+// code spans and blocks, links, images, block quotes, and thematic breaks.
+// Extensions such as tables and strikethrough are not recognized.
+//
+// Raw HTML in the input is escaped and shown as text, and link and image
+// URLs with a dangerous scheme such as javascript: are dropped, so the
+// result is safe to embed in a page. Void elements are written in XHTML
+// form, as in "<br />".
+func Markdown(input string) string {
+	return MarkdownWithOptions(input, MarkdownOptions{})
+}
+
+// MarkdownWithOptions is Markdown with raw HTML and hard line break
+// handling selected by opts.
+func MarkdownWithOptions(input string, opts MarkdownOptions) string {
+	var buf bytes.Buffer
+	// Rendering into a bytes.Buffer cannot fail.
+	_ = markdownConverters[opts].Convert([]byte(input), &buf)
+	return buf.String()
+}
+
+// markdownConverters holds one goldmark instance for every MarkdownOptions
+// value, built once so each call only parses and renders. The map and the
+// instances are read-only after initialization, and goldmark converters
+// are safe for concurrent use.
+var markdownConverters = func() map[MarkdownOptions]goldmark.Markdown {
+	converters := make(map[MarkdownOptions]goldmark.Markdown, 4)
+	for _, rawHTML := range []bool{false, true} {
+		for _, hardWraps := range []bool{false, true} {
+			opts := MarkdownOptions{RawHTML: rawHTML, HardWraps: hardWraps}
+			converters[opts] = newMarkdownConverter(opts)
+		}
+	}
+	return converters
+}()
+
+func newMarkdownConverter(opts MarkdownOptions) goldmark.Markdown {
+	rendererOpts := []renderer.Option{gmhtml.WithXHTML()}
+	if opts.RawHTML {
+		rendererOpts = append(rendererOpts, gmhtml.WithUnsafe())
+	} else {
+		rendererOpts = append(rendererOpts, renderer.WithNodeRenderers(
+			util.Prioritized(escapedHTMLRenderer{}, 100),
+		))
+	}
+	if opts.HardWraps {
+		rendererOpts = append(rendererOpts, gmhtml.WithHardWraps())
+	}
+	return goldmark.New(goldmark.WithRendererOptions(rendererOpts...))
+}
+
+// escapedHTMLRenderer renders raw HTML nodes as escaped text instead of
+// goldmark's default "raw HTML omitted" comment.
+type escapedHTMLRenderer struct{}
+
+func (escapedHTMLRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(ast.KindRawHTML, renderEscapedRawHTML)
+	reg.Register(ast.KindHTMLBlock, renderEscapedHTMLBlock)
+}
+
+func renderEscapedRawHTML(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if entering {
+		segments := node.(*ast.RawHTML).Segments
+		for i := range segments.Len() {
+			segment := segments.At(i)
+			gmhtml.DefaultWriter.RawWrite(w, segment.Value(source))
+		}
+	}
+	return ast.WalkSkipChildren, nil
+}
+
+func renderEscapedHTMLBlock(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+	n := node.(*ast.HTMLBlock)
+	var block bytes.Buffer
+	for i := range n.Lines().Len() {
+		line := n.Lines().At(i)
+		block.Write(line.Value(source))
+	}
+	if n.HasClosure() {
+		block.Write(n.ClosureLine.Value(source))
+	}
+	_, _ = w.WriteString("<p>")
+	gmhtml.DefaultWriter.RawWrite(w, bytes.TrimRight(block.Bytes(), "\n"))
+	_, _ = w.WriteString("</p>\n")
+	return ast.WalkSkipChildren, nil
+}
+
+// MarkdownToText renders input as CommonMark and returns its text without
+// markup, for excerpts and search snippets: emphasis, link, and image
+// syntax is dropped, keeping link text and image alt text, and character
+// references and backslash escapes are resolved. Blocks are separated by
+// a blank line and list items by a line break; soft line breaks inside a
+// paragraph become spaces. Inline raw HTML tags are dropped and HTML
+// blocks are converted with HTMLToText.
+//
+// Combine it with TruncateWords for a fixed-length excerpt.
+func MarkdownToText(input string) string {
+	source := []byte(input)
+	doc := goldmark.DefaultParser().Parse(text.NewReader(source))
+	t := &markdownText{source: source}
+	// The walker never returns an error.
+	_ = ast.Walk(doc, t.walk)
+	return t.b.String()
+}
+
+type markdownText struct {
+	source []byte
+	b      strings.Builder
+}
+
+func (t *markdownText) walk(node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+	switch n := node.(type) {
+	case *ast.Paragraph, *ast.TextBlock, *ast.Heading:
+		t.startBlock(node)
+	case *ast.CodeBlock, *ast.FencedCodeBlock:
+		t.startBlock(node)
+		t.b.WriteString(strings.TrimRight(t.lines(node), "\n"))
+		return ast.WalkSkipChildren, nil
+	case *ast.HTMLBlock:
+		lines := t.lines(node)
+		if n.HasClosure() {
+			lines += string(n.ClosureLine.Value(t.source))
+		}
+		if s := HTMLToText(lines); s != "" {
+			t.startBlock(node)
+			t.b.WriteString(s)
+		}
+		return ast.WalkSkipChildren, nil
+	case *ast.Text:
+		t.writeText(n.Segment.Value(t.source), n.IsRaw())
+		switch {
+		case n.HardLineBreak():
+			t.b.WriteByte('\n')
+		case n.SoftLineBreak():
+			t.b.WriteByte(' ')
+		}
+	case *ast.String:
+		t.writeText(n.Value, n.IsRaw())
+	case *ast.AutoLink:
+		t.b.Write(n.Label(t.source))
+	case *ast.RawHTML:
+		return ast.WalkSkipChildren, nil
+	}
+	return ast.WalkContinue, nil
+}
+
+// startBlock separates a new leaf block from the text before it.
+func (t *markdownText) startBlock(node ast.Node) {
+	if t.b.Len() == 0 {
+		return
+	}
+	if isTightListBlock(node) {
+		t.b.WriteByte('\n')
+		return
+	}
+	t.b.WriteString("\n\n")
+}
+
+// isTightListBlock reports whether node is a block inside a list item that
+// follows other text of the same list, and so only needs a line break.
+func isTightListBlock(node ast.Node) bool {
+	item := node.Parent()
+	if item == nil || item.Kind() != ast.KindListItem {
+		return false
+	}
+	if node.PreviousSibling() != nil || item.PreviousSibling() != nil {
+		return true
+	}
+	list := item.Parent()
+	return list != nil && list.Parent() != nil && list.Parent().Kind() == ast.KindListItem
+}
+
+func (t *markdownText) lines(node ast.Node) string {
+	var b strings.Builder
+	lines := node.Lines()
+	for i := range lines.Len() {
+		line := lines.At(i)
+		b.Write(line.Value(t.source))
+	}
+	return b.String()
+}
+
+// writeText writes an inline text value, resolving character references
+// and backslash escapes exactly as the HTML renderer does.
+func (t *markdownText) writeText(value []byte, raw bool) {
+	if raw {
+		t.b.Write(value)
+		return
+	}
+	var escaped bytes.Buffer
+	w := bufio.NewWriter(&escaped)
+	gmhtml.DefaultWriter.Write(w, value)
+	_ = w.Flush()
+	t.b.WriteString(html.UnescapeString(escaped.String()))
+}
@@ -0,0 +1,198 @@
+package filter
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/html"
+)
+
+func TestMarkdown(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"Empty", "", ""},
+		{"Paragraph", "Hello world", "<p>Hello world</p>\n"},
+		{"ATX Heading", "## Title", "<h2>Title</h2>\n"},
+		{"Setext Heading", "Title\n=====", "<h1>Title</h1>\n"},
+		{"Emphasis", "*em* _em_ **strong** __strong__", "<p><em>em</em> <em>em</em> <strong>strong</strong> <strong>strong</strong></p>\n"},
+		{"Code Span", "use `a < b`", "<p>use <code>a &lt; b</code></p>\n"},
+		{"Fenced Code", "```go\nif a < b {}\n```", "<pre><code class=\"language-go\">if a &lt; b {}\n</code></pre>\n"},
+		{"Indented Code", "    x := 1", "<pre><code>x := 1\n</code></pre>\n"},
+		{"Link", `[Go](https://go.dev "The Go site")`, "<p><a href=\"https://go.dev\" title=\"The Go site\">Go</a></p>\n"},
+		{"Reference Link", "[Go][1]\n\n[1]: https://go.dev", "<p><a href=\"https://go.dev\">Go</a></p>\n"},
+		{"Autolink", "<https://go.dev>", "<p><a href=\"https://go.dev\">https://go.dev</a></p>\n"},
+		{"Image", "![Gopher](gopher.png)", "<p><img src=\"gopher.png\" alt=\"Gopher\" /></p>\n"},
+		{"Unordered List", "- a\n- b", "<ul>\n<li>a</li>\n<li>b</li>\n</ul>\n"},
+		{"Ordered List Start", "3. a\n4. b", "<ol start=\"3\">\n<li>a</li>\n<li>b</li>\n</ol>\n"},
+		{"Blockquote", "> quoted", "<blockquote>\n<p>quoted</p>\n</blockquote>\n"},
+		{"Thematic Break", "a\n\n***", "<p>a</p>\n<hr />\n"},
+		{"Hard Line Break", "a  \nb", "<p>a<br />\nb</p>\n"},
+		{"Soft Line Break", "a\nb", "<p>a\nb</p>\n"},
+		{"Entities", "&copy; &amp; &#35;", "<p>© &amp; #</p>\n"},
+		{"Backslash Escape", `\*not em\*`, "<p>*not em*</p>\n"},
+		{"Text Escaped", `5 > 3 & "x"`, "<p>5 &gt; 3 &amp; &quot;x&quot;</p>\n"},
+		{"No Tables Extension", "| a |\n|---|", "<p>| a |\n|---|</p>\n"},
+		{"No Strikethrough Extension", "~~x~~", "<p>~~x~~</p>\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tt.want, Markdown(tt.input))
+		})
+	}
+}
+
+func TestMarkdownEscapesRawHTML(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"Inline", `Hi <b onclick="x()">there</b>`, "<p>Hi &lt;b onclick=&quot;x()&quot;&gt;there&lt;/b&gt;</p>\n"},
+		{"Block", "<script>\nalert(1)\n</script>", "<p>&lt;script&gt;\nalert(1)\n&lt;/script&gt;</p>\n"},
+		{"Comment", "<!-- secret -->", "<p>&lt;!-- secret --&gt;</p>\n"},
+		{"JavaScript Link", "[x](javascript:alert(1))", "<p><a href=\"\">x</a></p>\n"},
+		{"JavaScript Image", "![x](javascript:alert(1))", "<p><img src=\"\" alt=\"x\" /></p>\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tt.want, Markdown(tt.input))
+		})
+	}
+}
+
+func TestMarkdownWithOptions(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, Markdown("a <b>c</b>"), MarkdownWithOptions("a <b>c</b>", MarkdownOptions{}))
+
+	raw := MarkdownOptions{RawHTML: true}
+	require.Equal(t, "<p>a <b>c</b></p>\n", MarkdownWithOptions("a <b>c</b>", raw))
+	require.Equal(t, "<div>\n*x*\n</div>\n", MarkdownWithOptions("<div>\n*x*\n</div>\n", raw))
+	require.Equal(t, "<p><a href=\"javascript:alert(1)\">x</a></p>\n", MarkdownWithOptions("[x](javascript:alert(1))", raw))
+
+	require.Equal(t, "<p>a<br />\nb</p>\n", MarkdownWithOptions("a\nb", MarkdownOptions{HardWraps: true}))
+}
+
+func TestMarkdownWithOptionsCombined(t *testing.T) {
+	t.Parallel()
+
+	both := MarkdownOptions{RawHTML: true, HardWraps: true}
+	require.Equal(t, "<p>a <b>c</b><br />\nd</p>\n", MarkdownWithOptions("a <b>c</b>\nd", both))
+	require.Equal(t, "<p>a &lt;b&gt;c&lt;/b&gt;<br />\nd</p>\n", MarkdownWithOptions("a <b>c</b>\nd", MarkdownOptions{HardWraps: true}))
+}
+
+func TestMarkdownConcurrent(t *testing.T) {
+	t.Parallel()
+
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Go(func() {
+			opts := MarkdownOptions{RawHTML: i%2 == 0, HardWraps: i%4 < 2}
+			want := MarkdownWithOptions("# Title\n\na <i>b</i>\nc", opts)
+			for range 50 {
+				if got := MarkdownWithOptions("# Title\n\na <i>b</i>\nc", opts); got != want {
+					t.Errorf("MarkdownWithOptions(%+v) = %q, want %q", opts, got, want)
+					return
+				}
+			}
+		})
+	}
+	wg.Wait()
+}
+
+func TestMarkdownToText(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"Empty", "", ""},
+		{"Inline Markup Dropped", "Hello *world* and **bold** `code`", "Hello world and bold code"},
+		{"Blocks", "# Title\n\nFirst paragraph.\n\nSecond\nparagraph.", "Title\n\nFirst paragraph.\n\nSecond paragraph."},
+		{"Lists", "Intro\n\n- a\n- b\n  - c\n\n1. one\n2. two", "Intro\n\na\nb\nc\n\none\ntwo"},
+		{"Loose List", "- a\n\n  more\n- b", "a\nmore\nb"},
+		{"Link Text Kept", "[Go](https://go.dev) and <https://pkg.go.dev>", "Go and https://pkg.go.dev"},
+		{"Image Alt Kept", "![A gopher](g.png)", "A gopher"},
+		{"Entities And Escapes", `&copy; &amp; &#35; \*x\*`, "© & # *x*"},
+		{"Code Span Is Literal", "`&amp; \\*`", `&amp; \*`},
+		{"Code Block", "Intro\n\n```\na < b\n  indented\n```", "Intro\n\na < b\n  indented"},
+		{"Blockquote", "> quoted\n> text", "quoted text"},
+		{"Hard Break", "a\\\nb", "a\nb"},
+		{"Inline HTML Dropped", "a <b>bold</b> move", "a bold move"},
+		{"HTML Block Converted", "<div>\n<p>Hi &amp; bye</p>\n</div>\n\nAfter", "Hi & bye\n\nAfter"},
+		{"Script Block Dropped", "<script>\nalert(1)\n</script>\n\nAfter", "After"},
+		{"Thematic Break", "a\n\n---\n\nb", "a\n\nb"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tt.want, MarkdownToText(tt.input))
+		})
+	}
+}
+
+func TestMarkdownToTextExcerpt(t *testing.T) {
+	t.Parallel()
+
+	input := "## Release notes\n\nThis release adds **Markdown** support and [new docs](https://example.com)."
+	require.Equal(t, "Release notes\n\nThis release adds...", TruncateWords(MarkdownToText(input), 5))
+}
+
+func FuzzMarkdown(f *testing.F) {
+	f.Add("# a\n\n*b* <i>c</i> [d](javascript:e)")
+	f.Add("- a\n  - b\n\n> c")
+	f.Fuzz(func(t *testing.T, input string) {
+		out := Markdown(input)
+		z := html.NewTokenizer(strings.NewReader(out))
+		for tt := z.Next(); tt != html.ErrorToken; tt = z.Next() {
+			if tt != html.StartTagToken && tt != html.SelfClosingTagToken && tt != html.EndTagToken {
+				continue
+			}
+			tok := z.Token()
+			if !markdownElements[tok.Data] {
+				t.Fatalf("Markdown(%q) = %q contains <%s>", input, out, tok.Data)
+			}
+			for _, a := range tok.Attr {
+				url := strings.ToLower(strings.TrimSpace(a.Val))
+				if strings.HasPrefix(a.Key, "on") || strings.HasPrefix(url, "javascript:") || strings.HasPrefix(url, "vbscript:") {
+					t.Fatalf("Markdown(%q) = %q contains %s=%q", input, out, a.Key, a.Val)
+				}
+			}
+		}
+		_ = MarkdownToText(input)
+	})
+}
+
+// markdownElements lists the elements Markdown produces without raw HTML.
+var markdownElements = map[string]bool{
+	"a": true, "blockquote": true, "br": true, "code": true, "em": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"hr": true, "img": true, "li": true, "ol": true, "p": true, "pre": true,
+	"strong": true, "ul": true,
+}
+
+func BenchmarkMarkdown(b *testing.B) {
+	input := "# Title\n\nSome *emphasis*, a [link](https://example.com), and `code`.\n\n- one\n- two\n"
+	for b.Loop() {
+		Markdown(input)
+	}
+}
@@ -0,0 +1,285 @@
+package filter
+
+import (
+	"errors"
+	"fmt"
+	"unicode/utf8"
+)
+
+// Glob reports whether input matches the wildcard pattern as a whole:
+//
+//   - "*" matches any run of characters, including none and including "/".
+//   - "?" matches exactly one character.
+//   - "[abc]" matches one listed character, "[a-z]" one in the range, and
+//     "[!a-z]" or "[^a-z]" one not in it. A "]" listed first is literal.
+//   - "\" makes the next character literal, inside or outside brackets.
+//
+// Matching is case-sensitive and counts runes, not bytes. A malformed
+// pattern, such as an unclosed "[" or a trailing "\", returns
+// *Error{Kind: KindFormat}.
+func Glob(input, pattern string) (bool, error) {
+	items, err := compileGlob(pattern)
+	if err != nil {
+		return false, formatErr("Glob", err)
+	}
+	return items.match(input), nil
+}
+
+type globKind uint8
+
+const (
+	globLiteral globKind = iota
+	globAnyRune
+	globStar
+	globClass
+)
+
+type globItem struct {
+	kind   globKind
+	r      rune        // globLiteral
+	ranges []runeRange // globClass
+	negate bool        // globClass
+}
+
+type globPattern []globItem
+
+var (
+	errGlobDanglingEscape = errors.New("trailing backslash")
+	errGlobUnclosedClass  = errors.New("unclosed character class")
+)
+
+func compileGlob(pattern string) (globPattern, error) {
+	var items globPattern
+	for i := 0; i < len(pattern); {
+		r, size := utf8.DecodeRuneInString(pattern[i:])
+		i += size
+		switch r {
+		case '*':
+			if len(items) == 0 || items[len(items)-1].kind != globStar {
+				items = append(items, globItem{kind: globStar})
+			}
+		case '?':
+			items = append(items, globItem{kind: globAnyRune})
+		case '[':
+			item, n, err := compileGlobClass(pattern[i:])
+			if err != nil {
+				return nil, fmt.Errorf("glob %q: %w", pattern, err)
+			}
+			items = append(items, item)
+			i += n
+		case '\\':
+			if i >= len(pattern) {
+				return nil, fmt.Errorf("glob %q: %w", pattern, errGlobDanglingEscape)
+			}
+			r, size = utf8.DecodeRuneInString(pattern[i:])
+			i += size
+			items = append(items, globItem{kind: globLiteral, r: r})
+		default:
+			items = append(items, globItem{kind: globLiteral, r: r})
+		}
+	}
+	return items, nil
+}
+
+// compileGlobClass parses the bracket expression that follows "[" and
+// returns the number of bytes consumed, including the closing "]".
+func compileGlobClass(s string) (globItem, int, error) {
+	item := globItem{kind: globClass}
+	i := 0
+	if i < len(s) && (s[i] == '!' || s[i] == '^') {
+		item.negate = true
+		i++
+	}
+	first := true
+	for {
+		if i >= len(s) {
+			return globItem{}, 0, errGlobUnclosedClass
+		}
+		if s[i] == ']' && !first {
+			return item, i + 1, nil
+		}
+		first = false
+		lo, n, err := globClassRune(s[i:])
+		if err != nil {
+			return globItem{}, 0, err
+		}
+		i += n
+		hi := lo
+		if i+1 < len(s) && s[i] == '-' && s[i+1] != ']' {
+			hi, n, err = globClassRune(s[i+1:])
+			if err != nil {
+				return globItem{}, 0, err
+			}
+			if hi < lo {
+				return globItem{}, 0, fmt.Errorf("invalid range %q-%q", lo, hi)
+			}
+			i += 1 + n
+		}
+		item.ranges = append(item.ranges, runeRange{lo: lo, hi: hi})
+	}
+}
+
+func globClassRune(s string) (rune, int, error) {
+	if s[0] != '\\' {
+		r, n := utf8.DecodeRuneInString(s)
+		return r, n, nil
+	}
+	if len(s) < 2 {
+		return 0, 0, errGlobUnclosedClass
+	}
+	r, n := utf8.DecodeRuneInString(s[1:])
+	return r, 1 + n, nil
+}
+
+func (item globItem) matches(r rune) bool {
+	switch item.kind {
+	case globLiteral:
+		return r == item.r
+	case globAnyRune:
+		return true
+	case globClass:
+		in := false
+		for _, rr := range item.ranges {
+			if rr.lo <= r && r <= rr.hi {
+				in = true
+				break
+			}
+		}
+		return in != item.negate
+	default:
+		return false
+	}
+}
+
+// match runs the classic greedy-star matcher: on a mismatch it retries from
+// the most recent "*" with one more character consumed, which is enough
+// because "*" matches anything. It runs in O(len(pattern)·len(input)).
+func (p globPattern) match(s string) bool {
+	runes := []rune(s)
+	pi, si := 0, 0
+	starPi, starSi := -1, 0
+	for si < len(runes) {
+		switch {
+		case pi < len(p) && p[pi].kind == globStar:
+			starPi, starSi = pi, si
+			pi++
+		case pi < len(p) && p[pi].matches(runes[si]):
+			pi++
+			si++
+		case starPi >= 0:
+			starSi++
+			pi, si = starPi+1, starSi
+		default:
+			return false
+		}
+	}
+	for pi < len(p) && p[pi].kind == globStar {
+		pi++
+	}
+	return pi == len(p)
+}
+
+// Levenshtein returns the edit distance between a and b: the minimum number
+// of single-rune insertions, deletions, and substitutions that turn one into
+// the other. Comparison is case-sensitive.
+func Levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) < len(rb) {
+		ra, rb = rb, ra
+	}
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+// Similarity returns the Levenshtein distance normalized to a score between
+// 0 (nothing in common) and 1 (identical): one minus the distance divided by
+// the rune length of the longer string. Two empty strings score 1.
+func Similarity(a, b string) float64 {
+	longest := max(utf8.RuneCountInString(a), utf8.RuneCountInString(b))
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(Levenshtein(a, b))/float64(longest)
+}
+
+// Jaro-Winkler parameters as defined by Winkler: the common prefix counts
+// for at most four runes, each adding a tenth of the remaining distance,
+// and only when the plain Jaro score exceeds 0.7.
+const (
+	jaroWinklerPrefixScale = 0.1
+	jaroWinklerMaxPrefix   = 4
+	jaroWinklerBoost       = 0.7
+)
+
+// JaroWinkler returns the Jaro-Winkler similarity of a and b, between 0 and
+// 1. It favors strings that share a prefix, which suits short strings such
+// as names. Two empty strings score 1; an empty and a non-empty string score
+// 0. Comparison is case-sensitive.
+func JaroWinkler(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	jaro := jaroSimilarity(ra, rb)
+	if jaro <= jaroWinklerBoost {
+		return jaro
+	}
+	prefix := 0
+	for prefix < min(len(ra), len(rb), jaroWinklerMaxPrefix) && ra[prefix] == rb[prefix] {
+		prefix++
+	}
+	return jaro + float64(prefix)*jaroWinklerPrefixScale*(1-jaro)
+}
+
+func jaroSimilarity(a, b []rune) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 1
+	}
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	window := max(max(len(a), len(b))/2-1, 0)
+	matchedA := make([]bool, len(a))
+	matchedB := make([]bool, len(b))
+	matches := 0
+	for i := range a {
+		lo, hi := max(i-window, 0), min(i+window+1, len(b))
+		for j := lo; j < hi; j++ {
+			if !matchedB[j] && a[i] == b[j] {
+				matchedA[i], matchedB[j] = true, true
+				matches++
+				break
+			}
+		}
+	}
+	if matches == 0 {
+		return 0
+	}
+	transpositions, j := 0, 0
+	for i := range a {
+		if !matchedA[i] {
+			continue
+		}
+		for !matchedB[j] {
+			j++
+		}
+		if a[i] != b[j] {
+			transpositions++
+		}
+		j++
+	}
+	m := float64(matches)
+	return (m/float64(len(a)) + m/float64(len(b)) + (m-float64(transpositions)/2)/m) / 3
+}
@@ -0,0 +1,170 @@
+package filter
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGlob(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input   string
+		pattern string
+		want    bool
+	}{
+		{"report.pdf", "*.pdf", true},
+		{"report.pdf.txt", "*.pdf", false},
+		{".pdf", "*.pdf", true},
+		{"inv-2024-0042", "inv-2024-*", true},
+		{"inv-2023-0042", "inv-2024-*", false},
+		{"docs/a/b.pdf", "*.pdf", true},
+		{"", "*", true},
+		{"", "", true},
+		{"a", "", false},
+		{"abc", "a?c", true},
+		{"ac", "a?c", false},
+		{"añc", "a?c", true},
+		{"file7", "file[0-9]", true},
+		{"filex", "file[0-9]", false},
+		{"filex", "file[!0-9]", true},
+		{"filex", "file[^0-9]", true},
+		{"b", "[abc]", true},
+		{"]", "[]]", true},
+		{"-", "[a-]", true},
+		{"x", "[!]]", true},
+		{"]", "[!]]", false},
+		{"*", `\*`, true},
+		{"a", `\*`, false},
+		{"a*b", `a\*b`, true},
+		{"axb", `a\*b`, false},
+		{"]", `[\]]`, true},
+		{"Report.PDF", "*.pdf", false},
+		{"aaaaaaaaaaaaaaaaaaaaaaaaaaaaab", "*a*a*a*a*a*a*a*c", false},
+		{"mississippi", "m*iss*ppi", true},
+		{"abcabd", "*abd", true},
+		{"é", "[à-ÿ]", true},
+	}
+
+	for _, tt := range tests {
+		got, err := Glob(tt.input, tt.pattern)
+		require.NoError(t, err, "Glob(%q, %q)", tt.input, tt.pattern)
+		require.Equal(t, tt.want, got, "Glob(%q, %q)", tt.input, tt.pattern)
+	}
+}
+
+func TestGlobMalformedPattern(t *testing.T) {
+	t.Parallel()
+
+	for _, pattern := range []string{`[abc`, `abc\`, `[]`, `[!`, `[z-a]`, `[a\`} {
+		_, err := Glob("abc", pattern)
+		require.ErrorIs(t, err, ErrFormat, "Glob(%q)", pattern)
+		var ferr *Error
+		require.True(t, errors.As(err, &ferr))
+		require.Equal(t, "Glob", ferr.Op)
+	}
+}
+
+func TestLevenshtein(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"", "abc", 3},
+		{"abc", "", 3},
+		{"kitten", "sitting", 3},
+		{"flaw", "lawn", 2},
+		{"same", "same", 0},
+		{"héllo", "hello", 1},
+		{"日本語", "日本", 1},
+		{"Case", "case", 1},
+	}
+
+	for _, tt := range tests {
+		require.Equal(t, tt.want, Levenshtein(tt.a, tt.b), "Levenshtein(%q, %q)", tt.a, tt.b)
+		require.Equal(t, tt.want, Levenshtein(tt.b, tt.a), "Levenshtein(%q, %q)", tt.b, tt.a)
+	}
+}
+
+func TestSimilarity(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		a, b string
+		want float64
+	}{
+		{"", "", 1},
+		{"abc", "", 0},
+		{"abc", "abc", 1},
+		{"kitten", "sitting", 1 - 3.0/7},
+		{"abcd", "wxyz", 0},
+		{"héllo", "hello", 0.8},
+	}
+
+	for _, tt := range tests {
+		require.InDelta(t, tt.want, Similarity(tt.a, tt.b), 1e-9, "Similarity(%q, %q)", tt.a, tt.b)
+	}
+}
+
+func TestJaroWinkler(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		a, b string
+		want float64
+	}{
+		{"", "", 1},
+		{"abc", "", 0},
+		{"abc", "abc", 1},
+		{"MARTHA", "MARHTA", 0.961111111},
+		{"DWAYNE", "DUANE", 0.84},
+		{"DIXON", "DICKSONX", 0.813333333},
+		{"abc", "xyz", 0},
+		{"crate", "trace", 0.733333333},
+	}
+
+	for _, tt := range tests {
+		require.InDelta(t, tt.want, JaroWinkler(tt.a, tt.b), 1e-6, "JaroWinkler(%q, %q)", tt.a, tt.b)
+		require.InDelta(t, tt.want, JaroWinkler(tt.b, tt.a), 1e-6, "JaroWinkler(%q, %q)", tt.b, tt.a)
+	}
+}
+
+func FuzzGlob(f *testing.F) {
+	f.Add("report.pdf", "*.pdf")
+	f.Add("inv-2024-1", "inv-[0-9]*-?")
+	f.Add("a*b", `a\*b`)
+	f.Fuzz(func(t *testing.T, input, pattern string) {
+		got, err := Glob(input, pattern)
+		if err != nil {
+			return
+		}
+		if star, _ := Glob(input, pattern+"*"); got && !star {
+			t.Fatalf("Glob(%q, %q) matched but the pattern with a trailing * did not", input, pattern)
+		}
+	})
+}
+
+func FuzzSimilarity(f *testing.F) {
+	f.Add("kitten", "sitting")
+	f.Add("", "héllo")
+	f.Fuzz(func(t *testing.T, a, b string) {
+		d := Levenshtein(a, b)
+		if d != Levenshtein(b, a) {
+			t.Fatalf("Levenshtein(%q, %q) is not symmetric", a, b)
+		}
+		if d > max(len([]rune(a)), len([]rune(b))) {
+			t.Fatalf("Levenshtein(%q, %q) = %d exceeds the longer length", a, b, d)
+		}
+		for _, score := range []float64{Similarity(a, b), JaroWinkler(a, b)} {
+			if math.IsNaN(score) || score < 0 || score > 1+1e-12 {
+				t.Fatalf("score %v for (%q, %q) outside [0, 1]", score, a, b)
+			}
+		}
+	})
+}
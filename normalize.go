@@ -0,0 +1,103 @@
+package filter
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Normalize converts input to the Unicode normalization form named by form:
+// "NFC", "NFD", "NFKC", or "NFKD", matched case-insensitively. NFC composes
+// "e" plus U+0301 into "é", NFD decomposes it, and the K forms also replace
+// compatibility characters such as "ﬁ" or "①" with their plain equivalents.
+// Any other form returns *Error{Kind: KindInvalidInput}.
+func Normalize(input, form string) (string, error) {
+	f, ok := normalizationForms[strings.ToUpper(form)]
+	if !ok {
+		return "", invalidInput("Normalize", fmt.Errorf("unknown normalization form %q", form))
+	}
+	return f.String(input), nil
+}
+
+var normalizationForms = map[string]norm.Form{
+	"NFC":  norm.NFC,
+	"NFD":  norm.NFD,
+	"NFKC": norm.NFKC,
+	"NFKD": norm.NFKD,
+}
+
+// Unaccent removes diacritical marks from Latin, Greek, and Cyrillic letters
+// while keeping input readable: "Crème Brûlée" becomes "Creme Brulee".
+// Letters are decomposed, the combining marks that follow a letter of those
+// scripts are dropped, and the result is recomposed to NFC. Marks in other
+// scripts are part of the spelling and are kept, so Japanese dakuten
+// ("が"), Devanagari vowel signs and virama ("हिन्दी"), and Hebrew points are
+// unchanged. Letters whose stroke or slash is not a combining mark, such as
+// "ø", "ł", and "đ", are mapped to their base letter. Ligatures and letters
+// such as "æ" and "ß" are kept as they are; use Slugify to transliterate.
+func Unaccent(input string) string {
+	var b strings.Builder
+	b.Grow(len(input))
+	strip := false
+	for _, r := range norm.NFD.String(input) {
+		if unicode.Is(unicode.Mn, r) {
+			if strip {
+				continue
+			}
+		} else {
+			strip = unicode.In(r, unicode.Latin, unicode.Greek, unicode.Cyrillic)
+		}
+		b.WriteRune(unaccentLetter(r))
+	}
+	return norm.NFC.String(b.String())
+}
+
+// unaccentLetter maps letters with a non-decomposable diacritic to their
+// base letter.
+func unaccentLetter(r rune) rune {
+	switch r {
+	case 'Ø':
+		return 'O'
+	case 'ø':
+		return 'o'
+	case 'Ł':
+		return 'L'
+	case 'ł':
+		return 'l'
+	case 'Đ':
+		return 'D'
+	case 'đ':
+		return 'd'
+	case 'Ħ':
+		return 'H'
+	case 'ħ':
+		return 'h'
+	case 'Ŧ':
+		return 'T'
+	case 'ŧ':
+		return 't'
+	case 'ı':
+		return 'i'
+	default:
+		return r
+	}
+}
+
+// CaseFold returns a form of input for caseless comparison: two strings are
+// equal ignoring case exactly when their CaseFold results are equal. It
+// applies Unicode full case folding, so "Straße" and "STRASSE" both fold to
+// "strasse" and "ΣΊΣΥΦΟΣ" matches "σίσυφος", and it normalizes canonically
+// equivalent sequences, so a precomposed "é" matches "e" plus U+0301. The
+// result is in NFC. Use it for comparison, not for display.
+func CaseFold(input string) string {
+	t := transform.Chain(norm.NFD, cases.Fold(), norm.NFC)
+	out, _, err := transform.String(t, input)
+	if err != nil {
+		return input
+	}
+	return out
+}
@@ -0,0 +1,102 @@
+package filter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalize(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input string
+		form  string
+		want  string
+	}{
+		{"NFC Composes", "é", "NFC", "é"},
+		{"NFD Decomposes", "é", "NFD", "é"},
+		{"NFKC Ligature", "ﬁle", "NFKC", "file"},
+		{"NFKC Circled Digit", "①", "NFKC", "1"},
+		{"NFKD Decomposes Compatibility And Canonical", "éﬁ", "NFKD", "éfi"},
+		{"NFC Keeps Compatibility Characters", "ﬁ", "NFC", "ﬁ"},
+		{"Form Is Case Insensitive", "é", "nfc", "é"},
+		{"Hangul Jamo Compose", "가", "NFC", "가"},
+		{"Empty", "", "NFD", ""},
+		{"ASCII Unchanged", "hello", "NFKD", "hello"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := Normalize(tt.input, tt.form)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNormalizeUnknownForm(t *testing.T) {
+	t.Parallel()
+
+	for _, form := range []string{"", "NFX", "NFC "} {
+		_, err := Normalize("x", form)
+		require.ErrorIs(t, err, ErrInvalidInput, "form %q", form)
+	}
+}
+
+func TestUnaccent(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"", ""},
+		{"Crème Brûlée", "Creme Brulee"},
+		{"élève", "eleve"},
+		{"Ångström", "Angstrom"},
+		{"São Paulo", "Sao Paulo"},
+		{"Łódź", "Lodz"},
+		{"Søren Kierkegaard", "Soren Kierkegaard"},
+		{"Đorđe", "Dorde"},
+		{"Straße Æsir œuvre", "Straße Æsir œuvre"},
+		{"Ελληνικά", "Ελληνικα"},
+		{"Привет", "Привет"},
+		{"naïve café", "naive cafe"},
+		{"ﬁ", "ﬁ"},
+		{"Ёжик й", "Ежик и"},
+		{"がぎぐ パピプ", "がぎぐ パピプ"},
+		{"हिन्दी", "हिन्दी"},
+		{"שָׁלוֹם", "שָׁלוֹם"},
+		{"e\u0301 \u0301x", "e \u0301x"},
+	}
+
+	for _, tt := range tests {
+		require.Equal(t, tt.want, Unaccent(tt.input), "Unaccent(%q)", tt.input)
+	}
+}
+
+func TestCaseFold(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		a, b string
+	}{
+		{"Hello", "hELLO"},
+		{"Straße", "STRASSE"},
+		{"ΣΊΣΥΦΟΣ", "σίσυφος"},
+		{"é", "É"},
+		{"ǅ", "Ǆ"},
+		{"ﬃ", "FFI"},
+	}
+
+	for _, tt := range tests {
+		require.Equal(t, CaseFold(tt.a), CaseFold(tt.b), "CaseFold(%q) vs CaseFold(%q)", tt.a, tt.b)
+	}
+	require.Equal(t, "strasse", CaseFold("Straße"))
+	require.NotEqual(t, CaseFold("resume"), CaseFold("résumé"))
+	require.Equal(t, "", CaseFold(""))
+}
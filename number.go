@@ -1,6 +1,7 @@
 package filter
 
 import (
+	"encoding/json"
 	"math"
 	"strconv"
 	"strings"
@@ -110,6 +111,8 @@ func integerInputString(input any) (string, bool) {
 		return strconv.FormatUint(v, 10), true
 	case string:
 		return integerString(v)
+	case json.Number:
+		return integerString(string(v))
 	default:
 		return "", false
 	}
@@ -0,0 +1,152 @@
+package filter
+
+import (
+	"container/list"
+	"regexp"
+	"strconv"
+	"sync"
+)
+
+// RegexMatch reports whether input contains a match of pattern.
+//
+// All Regex filters take patterns in Go's RE2 syntax (package regexp), so
+// matching runs in time linear in the input. An invalid pattern returns
+// *Error{Kind: KindFormat} wrapping the *syntax.Error from regexp. Compiled
+// patterns are cached, so repeated calls with the same pattern are cheap.
+func RegexMatch(input, pattern string) (bool, error) {
+	re, err := compileRegex("RegexMatch", pattern)
+	if err != nil {
+		return false, err
+	}
+	return re.MatchString(input), nil
+}
+
+// RegexFind returns the leftmost match of pattern in input, or "" when there
+// is none.
+func RegexFind(input, pattern string) (string, error) {
+	re, err := compileRegex("RegexFind", pattern)
+	if err != nil {
+		return "", err
+	}
+	return re.FindString(input), nil
+}
+
+// RegexFindAll returns every non-overlapping match of pattern in input, one
+// map per match. Key "0" holds the whole match and "1", "2", ... the
+// numbered capture groups; named groups such as (?P<year>\d+) are also
+// stored under their name. A group that did not take part in the match maps
+// to "". No matches yield an empty slice.
+func RegexFindAll(input, pattern string) ([]map[string]string, error) {
+	re, err := compileRegex("RegexFindAll", pattern)
+	if err != nil {
+		return nil, err
+	}
+	names := re.SubexpNames()
+	matches := re.FindAllStringSubmatch(input, -1)
+	out := make([]map[string]string, len(matches))
+	for i, groups := range matches {
+		m := make(map[string]string, 2*len(groups))
+		for j, group := range groups {
+			m[strconv.Itoa(j)] = group
+			if names[j] != "" {
+				m[names[j]] = group
+			}
+		}
+		out[i] = m
+	}
+	return out, nil
+}
+
+// RegexReplace replaces every match of pattern in input with replacement,
+// in which $1 or ${1} stands for a numbered group and ${name} for a named
+// one; $$ is a literal dollar sign. As in regexp.Regexp.Expand, "$1x" means
+// the group named "1x", so write "${1}x" when a group is followed by a
+// letter, digit, or underscore.
+func RegexReplace(input, pattern, replacement string) (string, error) {
+	re, err := compileRegex("RegexReplace", pattern)
+	if err != nil {
+		return "", err
+	}
+	return re.ReplaceAllString(input, replacement), nil
+}
+
+// RegexSplit splits input around every match of pattern. As with
+// strings.SplitN, limit (default -1) caps the number of parts: a negative
+// limit returns all of them and zero returns an empty slice.
+func RegexSplit(input, pattern string, limit ...int) ([]string, error) {
+	re, err := compileRegex("RegexSplit", pattern)
+	if err != nil {
+		return nil, err
+	}
+	n := -1
+	if len(limit) > 0 {
+		n = limit[0]
+	}
+	parts := re.Split(input, n)
+	if parts == nil {
+		return []string{}, nil
+	}
+	return parts, nil
+}
+
+// regexCacheSize bounds the number of compiled patterns kept in memory.
+const regexCacheSize = 256
+
+// compiledRegexps caches compiled patterns so a template that applies the
+// same pattern to many values compiles it once. It holds no configuration
+// and never changes what a function returns.
+var compiledRegexps = newRegexCache(regexCacheSize)
+
+func compileRegex(op, pattern string) (*regexp.Regexp, error) {
+	re, err := compiledRegexps.compile(pattern)
+	if err != nil {
+		return nil, formatErr(op, err)
+	}
+	return re, nil
+}
+
+// regexCache is a mutex-guarded least-recently-used cache of compiled
+// patterns. Patterns that fail to compile are not cached.
+type regexCache struct {
+	mu      sync.Mutex
+	size    int
+	entries map[string]*list.Element
+	order   *list.List // *regexp.Regexp values, most recently used first
+}
+
+func newRegexCache(size int) *regexCache {
+	return &regexCache{size: size, entries: make(map[string]*list.Element, size), order: list.New()}
+}
+
+func (c *regexCache) compile(pattern string) (*regexp.Regexp, error) {
+	c.mu.Lock()
+	if e, ok := c.entries[pattern]; ok {
+		c.order.MoveToFront(e)
+		re := e.Value.(*regexp.Regexp)
+		c.mu.Unlock()
+		return re, nil
+	}
+	c.mu.Unlock()
+
+	// Compile outside the lock so a slow pattern does not block other
+	// lookups. Two goroutines may compile the same pattern; the first to
+	// store it wins.
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[pattern]; ok {
+		c.order.MoveToFront(e)
+		return e.Value.(*regexp.Regexp), nil
+	}
+	c.entries[pattern] = c.order.PushFront(re)
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*regexp.Regexp).String())
+	}
+	return re, nil
+}
@@ -0,0 +1,206 @@
+package filter
+
+import (
+	"errors"
+	"fmt"
+	"regexp/syntax"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegexMatch(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input   string
+		pattern string
+		want    bool
+	}{
+		{"hello world", `wor`, true},
+		{"hello world", `^world`, false},
+		{"order #1234", `#\d+`, true},
+		{"", `^$`, true},
+		{"HELLO", `(?i)hello`, true},
+		{"héllo", `^h.llo$`, true},
+	}
+
+	for _, tt := range tests {
+		got, err := RegexMatch(tt.input, tt.pattern)
+		require.NoError(t, err)
+		require.Equal(t, tt.want, got, "RegexMatch(%q, %q)", tt.input, tt.pattern)
+	}
+}
+
+func TestRegexFind(t *testing.T) {
+	t.Parallel()
+
+	got, err := RegexFind("order #1234 and #56", `#\d+`)
+	require.NoError(t, err)
+	require.Equal(t, "#1234", got)
+
+	got, err = RegexFind("no digits", `\d+`)
+	require.NoError(t, err)
+	require.Equal(t, "", got)
+}
+
+func TestRegexFindAll(t *testing.T) {
+	t.Parallel()
+
+	got, err := RegexFindAll("2024-03-31, 1999-12-01", `(?P<year>\d{4})-(\d{2})-(?P<day>\d{2})`)
+	require.NoError(t, err)
+	require.Equal(t, []map[string]string{
+		{"0": "2024-03-31", "1": "2024", "2": "03", "3": "31", "year": "2024", "day": "31"},
+		{"0": "1999-12-01", "1": "1999", "2": "12", "3": "01", "year": "1999", "day": "01"},
+	}, got)
+
+	got, err = RegexFindAll("a1 b", `([a-z])(\d)?`)
+	require.NoError(t, err)
+	require.Equal(t, []map[string]string{
+		{"0": "a1", "1": "a", "2": "1"},
+		{"0": "b", "1": "b", "2": ""},
+	}, got)
+
+	got, err = RegexFindAll("nothing here", `\d`)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	require.Empty(t, got)
+}
+
+func TestRegexReplace(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		input       string
+		pattern     string
+		replacement string
+		want        string
+	}{
+		{"Literal", "a-b-c", `-`, "+", "a+b+c"},
+		{"Numbered Groups", "John Smith", `(\w+) (\w+)`, "$2, $1", "Smith, John"},
+		{"Braced Group Before Letter", "abc", `(b)`, "${1}x", "abxc"},
+		{"Unbraced Group Before Letter", "abc", `(b)`, "$1x", "ac"},
+		{"Named Groups", "2024-03-31", `(?P<y>\d+)-(?P<m>\d+)-(?P<d>\d+)`, "${d}/${m}/${y}", "31/03/2024"},
+		{"Dollar Escape", "5", `(\d)`, "$$$1", "$5"},
+		{"No Match", "abc", `\d`, "x", "abc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := RegexReplace(tt.input, tt.pattern, tt.replacement)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRegexSplit(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		input   string
+		pattern string
+		limit   []int
+		want    []string
+	}{
+		{"Whitespace", "a  b\tc", `\s+`, nil, []string{"a", "b", "c"}},
+		{"Commas With Spaces", "a, b ,c", `\s*,\s*`, nil, []string{"a", "b", "c"}},
+		{"No Match", "abc", `,`, nil, []string{"abc"}},
+		{"Limit", "a,b,c,d", `,`, []int{2}, []string{"a", "b,c,d"}},
+		{"Zero Limit", "a,b", `,`, []int{0}, []string{}},
+		{"Negative Limit", "a,b", `,`, []int{-1}, []string{"a", "b"}},
+		{"Empty Input", "", `,`, nil, []string{""}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := RegexSplit(tt.input, tt.pattern, tt.limit...)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRegexInvalidPattern(t *testing.T) {
+	t.Parallel()
+
+	calls := map[string]func(string) error{
+		"RegexMatch":   func(p string) error { _, err := RegexMatch("x", p); return err },
+		"RegexFind":    func(p string) error { _, err := RegexFind("x", p); return err },
+		"RegexFindAll": func(p string) error { _, err := RegexFindAll("x", p); return err },
+		"RegexReplace": func(p string) error { _, err := RegexReplace("x", p, ""); return err },
+		"RegexSplit":   func(p string) error { _, err := RegexSplit("x", p); return err },
+	}
+
+	for op, call := range calls {
+		for _, pattern := range []string{`(`, `[a-`, `*x`, `(?P<>x)`, `\8`} {
+			err := call(pattern)
+			require.ErrorIs(t, err, ErrFormat, "%s(%q)", op, pattern)
+
+			var ferr *Error
+			require.True(t, errors.As(err, &ferr))
+			require.Equal(t, op, ferr.Op)
+
+			var serr *syntax.Error
+			require.True(t, errors.As(err, &serr), "%s(%q) should wrap *syntax.Error", op, pattern)
+		}
+	}
+}
+
+func TestRegexCache(t *testing.T) {
+	t.Parallel()
+
+	c := newRegexCache(2)
+	a1, err := c.compile(`a`)
+	require.NoError(t, err)
+	a2, err := c.compile(`a`)
+	require.NoError(t, err)
+	require.Same(t, a1, a2)
+
+	_, err = c.compile(`(`)
+	require.Error(t, err)
+	require.Equal(t, 1, c.order.Len())
+
+	_, _ = c.compile(`b`)
+	_, _ = c.compile(`a`) // a becomes most recently used
+	_, _ = c.compile(`c`) // evicts b
+	require.Equal(t, 2, c.order.Len())
+	require.Contains(t, c.entries, `a`)
+	require.Contains(t, c.entries, `c`)
+	require.NotContains(t, c.entries, `b`)
+	a3, _ := c.compile(`a`)
+	require.Same(t, a1, a3)
+}
+
+func TestRegexCacheConcurrent(t *testing.T) {
+	t.Parallel()
+
+	c := newRegexCache(8)
+	var wg sync.WaitGroup
+	for i := range 32 {
+		wg.Go(func() {
+			for j := range 100 {
+				re, err := c.compile(fmt.Sprintf(`x%d`, (i+j)%16))
+				if err != nil || re == nil {
+					t.Errorf("compile failed: %v", err)
+				}
+			}
+		})
+	}
+	wg.Wait()
+	require.LessOrEqual(t, c.order.Len(), 8)
+	require.Len(t, c.entries, c.order.Len())
+}
+
+func BenchmarkRegexReplace(b *testing.B) {
+	for b.Loop() {
+		_, _ = RegexReplace("2024-03-31", `(?P<y>\d+)-(?P<m>\d+)-(?P<d>\d+)`, "${d}/${m}/${y}")
+	}
+}
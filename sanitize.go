@@ -0,0 +1,291 @@
+package filter
+
+import (
+	"net/url"
+	"slices"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// HTMLPolicy is an allow-list for SanitizeHTML. Anything it does not name is
+// removed. The zero value allows no markup at all, so only escaped text
+// survives, exactly like StrictHTMLPolicy.
+type HTMLPolicy struct {
+	// Elements lists the element names, in lower case, that are kept.
+	// Other elements are removed but their text is kept.
+	Elements []string
+	// Attributes maps an element name to the attributes kept on it. The
+	// key "*" lists attributes kept on every allowed element.
+	Attributes map[string][]string
+	// URLSchemes lists the schemes, in lower case, allowed in attributes
+	// that hold a URL, such as href and src. An attribute whose URL has
+	// another scheme is removed.
+	URLSchemes []string
+	// AllowRelativeURLs keeps URL attributes without a scheme, such as
+	// "/about" or "#top".
+	AllowRelativeURLs bool
+	// AddRelNoFollow sets rel="nofollow" on every kept link with an href,
+	// adding it to any rel value the policy allows.
+	AddRelNoFollow bool
+}
+
+// StrictHTMLPolicy returns a policy that allows no markup: SanitizeHTML
+// then returns the input's text, escaped, without any tags.
+func StrictHTMLPolicy() HTMLPolicy {
+	return HTMLPolicy{}
+}
+
+// UGCHTMLPolicy returns a policy for basic user-generated content such as
+// comments: text formatting, headings, lists, quotes, code, tables, links,
+// and images, with http, https, and mailto URLs, relative URLs, and
+// rel="nofollow" on links. Each call returns a new value the caller may
+// modify.
+func UGCHTMLPolicy() HTMLPolicy {
+	return HTMLPolicy{
+		Elements: []string{
+			"a", "abbr", "b", "blockquote", "br", "caption", "cite", "code",
+			"dd", "del", "div", "dl", "dt", "em", "h1", "h2", "h3", "h4", "h5",
+			"h6", "hr", "i", "img", "ins", "kbd", "li", "mark", "ol", "p", "pre",
+			"q", "s", "small", "span", "strong", "sub", "sup", "table", "tbody",
+			"td", "tfoot", "th", "thead", "tr", "u", "ul",
+		},
+		Attributes: map[string][]string{
+			"*":          {"title", "lang"},
+			"a":          {"href"},
+			"blockquote": {"cite"},
+			"img":        {"src", "alt", "width", "height"},
+			"ol":         {"start"},
+			"q":          {"cite"},
+			"td":         {"colspan", "rowspan"},
+			"th":         {"colspan", "rowspan", "scope"},
+		},
+		URLSchemes:        []string{"http", "https", "mailto"},
+		AllowRelativeURLs: true,
+		AddRelNoFollow:    true,
+	}
+}
+
+// SanitizeHTML parses input as an HTML fragment and rebuilds it keeping
+// only the elements, attributes, and URLs policy allows. Text is
+// re-escaped, attribute values are quoted and escaped, and elements left
+// open are closed, so the result is safe to embed in an HTML page.
+//
+// Some things are removed whatever the policy says: comments, doctypes,
+// event handler attributes (on*), style attributes, base, link, and meta
+// elements, and script, style, iframe, object, embed, svg, math, and
+// similar elements together with their contents.
+func SanitizeHTML(input string, policy HTMLPolicy) string {
+	s := newHTMLSanitizer(policy)
+	z := html.NewTokenizer(strings.NewReader(input))
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			for i := len(s.open) - 1; i >= 0; i-- {
+				s.closeTag(s.open[i])
+			}
+			return s.b.String()
+		case html.TextToken:
+			if len(s.skip) == 0 {
+				s.b.WriteString(html.EscapeString(string(z.Text())))
+			}
+		case html.StartTagToken:
+			s.start(z.Token(), false)
+		case html.SelfClosingTagToken:
+			s.start(z.Token(), true)
+		case html.EndTagToken:
+			s.end(z.Token())
+		}
+	}
+}
+
+// sanitizeDropped lists elements removed with their contents whatever the
+// policy. Several are raw-text elements whose contents would otherwise be
+// emitted as text.
+var sanitizeDropped = map[atom.Atom]bool{
+	atom.Script: true, atom.Style: true, atom.Iframe: true, atom.Object: true,
+	atom.Embed: true, atom.Applet: true, atom.Svg: true, atom.Math: true,
+	atom.Template: true, atom.Noscript: true, atom.Noembed: true,
+	atom.Noframes: true, atom.Frameset: true, atom.Frame: true, atom.Xmp: true,
+	atom.Plaintext: true, atom.Textarea: true, atom.Title: true,
+	atom.Select: true, atom.Base: true, atom.Link: true, atom.Meta: true,
+}
+
+// sanitizeVoid lists elements that have no end tag.
+var sanitizeVoid = map[atom.Atom]bool{
+	atom.Area: true, atom.Base: true, atom.Br: true, atom.Col: true,
+	atom.Embed: true, atom.Hr: true, atom.Img: true, atom.Input: true,
+	atom.Link: true, atom.Meta: true, atom.Source: true, atom.Track: true,
+	atom.Wbr: true,
+}
+
+// sanitizeURLAttrs lists attributes whose value is a URL.
+var sanitizeURLAttrs = map[string]bool{
+	"action": true, "background": true, "cite": true, "formaction": true,
+	"href": true, "longdesc": true, "poster": true, "src": true,
+	"usemap": true, "xlink:href": true,
+}
+
+type htmlSanitizer struct {
+	policy     HTMLPolicy
+	elements   map[string]bool
+	attributes map[string]map[string]bool
+	b          strings.Builder
+	open       []string // allowed elements not yet closed
+	skip       []string // dropped elements whose contents are being skipped
+}
+
+func newHTMLSanitizer(policy HTMLPolicy) *htmlSanitizer {
+	s := &htmlSanitizer{
+		policy:     policy,
+		elements:   make(map[string]bool, len(policy.Elements)),
+		attributes: make(map[string]map[string]bool, len(policy.Attributes)),
+	}
+	for _, name := range policy.Elements {
+		s.elements[strings.ToLower(name)] = true
+	}
+	for name, attrs := range policy.Attributes {
+		set := make(map[string]bool, len(attrs))
+		for _, a := range attrs {
+			set[strings.ToLower(a)] = true
+		}
+		s.attributes[strings.ToLower(name)] = set
+	}
+	return s
+}
+
+func (s *htmlSanitizer) start(t html.Token, selfClosing bool) {
+	if sanitizeDropped[t.DataAtom] {
+		if !selfClosing && !sanitizeVoid[t.DataAtom] {
+			s.skip = append(s.skip, t.Data)
+		}
+		return
+	}
+	if len(s.skip) > 0 || !s.elements[t.Data] {
+		return
+	}
+	s.b.WriteByte('<')
+	s.b.WriteString(t.Data)
+	for _, a := range s.allowedAttrs(t) {
+		s.b.WriteByte(' ')
+		s.b.WriteString(a.Key)
+		s.b.WriteString(`="`)
+		s.b.WriteString(html.EscapeString(a.Val))
+		s.b.WriteByte('"')
+	}
+	if sanitizeVoid[t.DataAtom] {
+		s.b.WriteString(" />")
+		return
+	}
+	s.b.WriteByte('>')
+	if selfClosing {
+		s.closeTag(t.Data)
+		return
+	}
+	s.open = append(s.open, t.Data)
+}
+
+func (s *htmlSanitizer) end(t html.Token) {
+	if len(s.skip) > 0 {
+		if s.skip[len(s.skip)-1] == t.Data {
+			s.skip = s.skip[:len(s.skip)-1]
+		}
+		return
+	}
+	i := slices.Index(s.open, t.Data)
+	if i < 0 {
+		return
+	}
+	// Close any elements the input left open inside this one.
+	for j := len(s.open) - 1; j >= i; j-- {
+		s.closeTag(s.open[j])
+	}
+	s.open = s.open[:i]
+}
+
+func (s *htmlSanitizer) closeTag(name string) {
+	s.b.WriteString("</")
+	s.b.WriteString(name)
+	s.b.WriteByte('>')
+}
+
+// allowedAttrs returns the attributes of t the policy keeps, in input
+// order. Only the first of repeated attributes counts, as in browsers.
+func (s *htmlSanitizer) allowedAttrs(t html.Token) []html.Attribute {
+	var out []html.Attribute
+	seen := make(map[string]bool, len(t.Attr))
+	relIndex := -1
+	hasHref := false
+	for _, a := range t.Attr {
+		if seen[a.Key] {
+			continue
+		}
+		seen[a.Key] = true
+		if strings.HasPrefix(a.Key, "on") || a.Key == "style" {
+			continue
+		}
+		if !s.attributes[t.Data][a.Key] && !s.attributes["*"][a.Key] {
+			continue
+		}
+		switch {
+		case a.Key == "srcset":
+			v, ok := s.sanitizeSrcset(a.Val)
+			if !ok {
+				continue
+			}
+			a.Val = v
+		case sanitizeURLAttrs[a.Key]:
+			if !s.allowedURL(a.Val) {
+				continue
+			}
+			if a.Key == "href" {
+				hasHref = true
+			}
+		}
+		if a.Key == "rel" {
+			relIndex = len(out)
+		}
+		out = append(out, html.Attribute{Key: a.Key, Val: a.Val})
+	}
+	if s.policy.AddRelNoFollow && hasHref && (t.DataAtom == atom.A || t.DataAtom == atom.Area) {
+		if relIndex < 0 {
+			out = append(out, html.Attribute{Key: "rel", Val: "nofollow"})
+		} else if !slices.Contains(strings.Fields(strings.ToLower(out[relIndex].Val)), "nofollow") {
+			out[relIndex].Val = strings.TrimSpace(out[relIndex].Val + " nofollow")
+		}
+	}
+	return out
+}
+
+// allowedURL reports whether the policy allows rawURL. Like browsers, it
+// ignores surrounding whitespace and tabs or line breaks inside the URL,
+// so "java\tscript:" is seen as a javascript: URL.
+func (s *htmlSanitizer) allowedURL(rawURL string) bool {
+	cleaned := strings.Map(func(r rune) rune {
+		if r == '\t' || r == '\n' || r == '\r' {
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(rawURL))
+	u, err := url.Parse(cleaned)
+	if err != nil {
+		return false
+	}
+	if u.Scheme == "" {
+		return s.policy.AllowRelativeURLs
+	}
+	return slices.Contains(s.policy.URLSchemes, strings.ToLower(u.Scheme))
+}
+
+// sanitizeSrcset checks every URL of an img srcset value. It keeps the
+// value only when all of them are allowed.
+func (s *htmlSanitizer) sanitizeSrcset(value string) (string, bool) {
+	for candidate := range strings.SplitSeq(value, ",") {
+		fields := strings.Fields(candidate)
+		if len(fields) == 0 || !s.allowedURL(fields[0]) {
+			return "", false
+		}
+	}
+	return value, true
+}
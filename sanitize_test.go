@@ -0,0 +1,251 @@
+package filter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/html"
+)
+
+func TestSanitizeHTMLStrict(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"Empty", "", ""},
+		{"Plain Text", "Hello world", "Hello world"},
+		{"Tags Removed Text Kept", "<p>Hello <b>world</b></p>", "Hello world"},
+		{"Text Escaped", "1 < 2 & 3 > 2", "1 &lt; 2 &amp; 3 &gt; 2"},
+		{"Entities Normalized", "Tom &amp; Jerry &quot;&#39;", "Tom &amp; Jerry &#34;&#39;"},
+		{"Script Content Dropped", "a<script>alert(1)</script>b", "ab"},
+		{"Style Content Dropped", "a<style>body{}</style>b", "ab"},
+		{"Comment Dropped", "a<!-- <b>x</b> -->b", "ab"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tt.want, SanitizeHTML(tt.input, StrictHTMLPolicy()))
+			require.Equal(t, tt.want, SanitizeHTML(tt.input, HTMLPolicy{}))
+		})
+	}
+}
+
+func TestSanitizeHTMLUGC(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"Formatting Kept", "<p>Hello <b>bold</b> <em>em</em></p>", "<p>Hello <b>bold</b> <em>em</em></p>"},
+		{"Tag Case Normalized", "<P>Hi<BR></P>", "<p>Hi<br /></p>"},
+		{"Unknown Element Unwrapped", "<font color=red><b>x</b></font>", "<b>x</b>"},
+		{"Link Gets Nofollow", `<a href="https://example.com">x</a>`, `<a href="https://example.com" rel="nofollow">x</a>`},
+		{"Relative Link Kept", `<a href="/about">x</a>`, `<a href="/about" rel="nofollow">x</a>`},
+		{"Mailto Kept", `<a href="mailto:me@example.com">x</a>`, `<a href="mailto:me@example.com" rel="nofollow">x</a>`},
+		{"Disallowed Scheme Removed", `<a href="ftp://example.com">x</a>`, `<a>x</a>`},
+		{"Disallowed Attribute Removed", `<a href="/x" target="_blank" class="c">x</a>`, `<a href="/x" rel="nofollow">x</a>`},
+		{"Global Attribute Kept", `<span title="t" lang="en" id="i">x</span>`, `<span title="t" lang="en">x</span>`},
+		{"Attribute Value Escaped", `<span title='a"b<c>'>x</span>`, `<span title="a&#34;b&lt;c&gt;">x</span>`},
+		{"Repeated Attribute Uses First", `<span title="a" title="b">x</span>`, `<span title="a">x</span>`},
+		{"Image Kept", `<img src="https://example.com/a.png" alt="A">`, `<img src="https://example.com/a.png" alt="A" />`},
+		{"Unclosed Elements Closed", "<ul><li><b>x", "<ul><li><b>x</b></li></ul>"},
+		{"Misnested Elements Closed", "<b><i>x</b>y</i>", "<b><i>x</i></b>y"},
+		{"Stray End Tag Dropped", "x</b></div>", "x"},
+		{"Self Closing Non-Void", "<p/>x", "<p></p>x"},
+		{"Table Attributes", `<table><tr><td colspan="2" width="9">x</td></tr></table>`, `<table><tr><td colspan="2">x</td></tr></table>`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tt.want, SanitizeHTML(tt.input, UGCHTMLPolicy()))
+		})
+	}
+}
+
+func TestSanitizeHTMLCustomPolicy(t *testing.T) {
+	t.Parallel()
+
+	policy := HTMLPolicy{
+		Elements:   []string{"A", "img"},
+		Attributes: map[string][]string{"a": {"HREF", "rel"}, "img": {"src", "srcset"}},
+		URLSchemes: []string{"https"},
+	}
+
+	require.Equal(t, `<a href="https://x.test">x</a>`, SanitizeHTML(`<a href="https://x.test">x</a>`, policy))
+	require.Equal(t, `<a>x</a>`, SanitizeHTML(`<a href="/relative">x</a>`, policy), "relative URLs need AllowRelativeURLs")
+	require.Equal(t, `<a>x</a>`, SanitizeHTML(`<a href="http://x.test">x</a>`, policy))
+	require.Equal(t, `<a href="https://x.test" rel="noopener">x</a>`, SanitizeHTML(`<a href="https://x.test" rel="noopener">x</a>`, policy))
+
+	policy.AddRelNoFollow = true
+	require.Equal(t, `<a href="https://x.test" rel="noopener nofollow">x</a>`, SanitizeHTML(`<a href="https://x.test" rel="noopener">x</a>`, policy))
+	require.Equal(t, `<a href="https://x.test" rel="NoFollow">x</a>`, SanitizeHTML(`<a href="https://x.test" rel="NoFollow">x</a>`, policy))
+	require.Equal(t, `<a>x</a>`, SanitizeHTML(`<a>x</a>`, policy), "no rel without an href")
+
+	require.Equal(t, `<img srcset="https://x.test/a.png 1x, https://x.test/b.png 2x" />`,
+		SanitizeHTML(`<img srcset="https://x.test/a.png 1x, https://x.test/b.png 2x">`, policy))
+	require.Equal(t, `<img />`, SanitizeHTML(`<img srcset="https://x.test/a.png 1x, javascript:alert(1) 2x">`, policy))
+}
+
+func TestSanitizeHTMLPoliciesAreIndependent(t *testing.T) {
+	t.Parallel()
+
+	p := UGCHTMLPolicy()
+	p.Elements = p.Elements[:0]
+	p.Attributes["a"] = nil
+	require.Equal(t, `<b>x</b>`, SanitizeHTML("<b>x</b>", UGCHTMLPolicy()))
+	require.Equal(t, `<a href="/" rel="nofollow">x</a>`, SanitizeHTML(`<a href="/">x</a>`, UGCHTMLPolicy()))
+}
+
+// xssVectors is a corpus of known cross-site scripting payloads, drawn
+// from the OWASP filter evasion cheat sheet and mutation XSS reports.
+var xssVectors = []string{
+	`<script>alert('XSS')</script>`,
+	`<SCRIPT SRC=http://xss.rocks/xss.js></SCRIPT>`,
+	`<IMG SRC="javascript:alert('XSS');">`,
+	`<IMG SRC=javascript:alert('XSS')>`,
+	`<IMG SRC=JaVaScRiPt:alert('XSS')>`,
+	"<IMG SRC=`javascript:alert(\"RSnake says, 'XSS'\")`>",
+	`<a onmouseover="alert(document.cookie)">xxs link</a>`,
+	`<a onmouseover=alert(document.cookie)>xxs link</a>`,
+	`<IMG """><SCRIPT>alert("XSS")</SCRIPT>"\>`,
+	`<IMG SRC=javascript:alert(String.fromCharCode(88,83,83))>`,
+	`<IMG SRC=# onmouseover="alert('xxs')">`,
+	`<IMG SRC= onmouseover="alert('xxs')">`,
+	`<IMG onmouseover="alert('xxs')">`,
+	`<IMG SRC=/ onerror="alert(String.fromCharCode(88,83,83))"></img>`,
+	`<img src=x onerror="&#0000106&#0000097&#0000118&#0000097&#0000115&#0000099&#0000114&#0000105&#0000112&#0000116&#0000058&#0000097&#0000108&#0000101&#0000114&#0000116&#0000040&#0000039&#0000088&#0000083&#0000083&#0000039&#0000041">`,
+	`<IMG SRC=&#106;&#97;&#118;&#97;&#115;&#99;&#114;&#105;&#112;&#116;&#58;&#97;&#108;&#101;&#114;&#116;&#40;&#39;&#88;&#83;&#83;&#39;&#41;>`,
+	`<IMG SRC=&#0000106&#0000097&#0000118&#0000097&#0000115&#0000099&#0000114&#0000105&#0000112&#0000116&#0000058&#0000097&#0000108&#0000101&#0000114&#0000116&#0000040&#0000039&#0000088&#0000083&#0000083&#0000039&#0000041>`,
+	`<IMG SRC=&#x6A&#x61&#x76&#x61&#x73&#x63&#x72&#x69&#x70&#x74&#x3A&#x61&#x6C&#x65&#x72&#x74&#x28&#x27&#x58&#x53&#x53&#x27&#x29>`,
+	`<IMG SRC="jav	ascript:alert('XSS');">`,
+	`<IMG SRC="jav&#x09;ascript:alert('XSS');">`,
+	`<IMG SRC="jav&#x0A;ascript:alert('XSS');">`,
+	`<IMG SRC="jav&#x0D;ascript:alert('XSS');">`,
+	"<IMG SRC=\"java\x00script:alert('XSS');\">",
+	`<IMG SRC=" &#14;  javascript:alert('XSS');">`,
+	`<SCRIPT/XSS SRC="http://xss.rocks/xss.js"></SCRIPT>`,
+	"<BODY onload!#$%&()*~+-_.,:;?@[/|\\]^`=alert(\"XSS\")>",
+	`<SCRIPT/SRC="http://xss.rocks/xss.js"></SCRIPT>`,
+	`<<SCRIPT>alert("XSS");//\<</SCRIPT>`,
+	`<SCRIPT SRC=http://xss.rocks/xss.js?< B >`,
+	`<SCRIPT SRC=//xss.rocks/.j>`,
+	`<IMG SRC="` + "`" + `javascript:alert('XSS')"`,
+	`<iframe src=http://xss.rocks/scriptlet.html <`,
+	`</TITLE><SCRIPT>alert("XSS");</SCRIPT>`,
+	`<INPUT TYPE="IMAGE" SRC="javascript:alert('XSS');">`,
+	`<BODY BACKGROUND="javascript:alert('XSS')">`,
+	`<IMG DYNSRC="javascript:alert('XSS')">`,
+	`<IMG LOWSRC="javascript:alert('XSS')">`,
+	`<STYLE>li {list-style-image: url("javascript:alert('XSS')");}</STYLE><UL><LI>XSS</br>`,
+	`<svg/onload=alert('XSS')>`,
+	`<svg><script>alert(1)</script></svg>`,
+	`<math><mi xlink:href="javascript:alert(1)">x</mi></math>`,
+	`<BODY ONLOAD=alert('XSS')>`,
+	`<BGSOUND SRC="javascript:alert('XSS');">`,
+	`<BR SIZE="&{alert('XSS')}">`,
+	`<LINK REL="stylesheet" HREF="javascript:alert('XSS');">`,
+	`<META HTTP-EQUIV="refresh" CONTENT="0;url=javascript:alert('XSS');">`,
+	`<META HTTP-EQUIV="refresh" CONTENT="0;url=data:text/html base64,PHNjcmlwdD5hbGVydCgnWFNTJyk8L3NjcmlwdD4K">`,
+	`<IFRAME SRC="javascript:alert('XSS');"></IFRAME>`,
+	`<FRAMESET><FRAME SRC="javascript:alert('XSS');"></FRAMESET>`,
+	`<TABLE BACKGROUND="javascript:alert('XSS')">`,
+	`<TABLE><TD BACKGROUND="javascript:alert('XSS')">`,
+	`<DIV STYLE="background-image: url(javascript:alert('XSS'))">`,
+	`<DIV STYLE="width: expression(alert('XSS'));">`,
+	`<IMG STYLE="xss:expr/*XSS*/ession(alert('XSS'))">`,
+	`<BASE HREF="javascript:alert('XSS');//">`,
+	`<OBJECT TYPE="text/x-scriptlet" DATA="http://xss.rocks/scriptlet.html"></OBJECT>`,
+	`<EMBED SRC="data:image/svg+xml;base64,PHN2ZyB4bWxuczpzdmc9Imh0dH A6Ly93d3cudzMub3JnLzIwMDAvc3ZnIiB4bWxucz0iaHR0cDovL3d3dy53My5vcmcv MjAwMC9zdmciIHhtbG5zOnhsaW5rPSJodHRwOi8vd3d3LnczLm9yZy8xOTk5L3hs aW5rIiB2ZXJzaW9uPSIxLjAiIHg9IjAiIHk9IjAiIHdpZHRoPSIxOTQiIGhlaWdodD0iMjAw IiBpZD0ieHNzIj48c2NyaXB0IHR5cGU9InRleHQvZWNtYXNjcmlwdCI+YWxlcnQoIlh TUyIpOzwvc2NyaXB0Pjwvc3ZnPg==" type="image/svg+xml" AllowScriptAccess="always"></EMBED>`,
+	`<a href="javascript&colon;alert(1)">x</a>`,
+	`<a href="&#x6a;avascript:alert(1)">x</a>`,
+	`<a href=" javascript:alert(1)">x</a>`,
+	`<a href="vbscript:msgbox(1)">x</a>`,
+	`<a href="data:text/html;base64,PHNjcmlwdD5hbGVydCgxKTwvc2NyaXB0Pg==">x</a>`,
+	`<a href="JAVASCRIPT:alert(1)">x</a>`,
+	`<a href="java&NewLine;script:alert(1)">x</a>`,
+	`<form action="javascript:alert(1)"><button formaction="javascript:alert(1)">x</button></form>`,
+	`<noscript><p title="</noscript><img src=x onerror=alert(1)>">`,
+	`<title><img src=x onerror=alert(1)></title>`,
+	`<textarea><script>alert(1)</script></textarea>`,
+	`<xmp><img src=x onerror=alert(1)></xmp>`,
+	`<template><img src=x onerror=alert(1)></template>`,
+	`<select><option><img src=x onerror=alert(1)></option></select>`,
+	`<details open ontoggle=alert(1)>`,
+	`<img src="x" alt="&quot; onerror=&quot;alert(1)">`,
+	`<a title="x" href='javascript:alert(1)'>x</a>`,
+	`<!--<img src="--><img src=x onerror=alert(1)//">`,
+	`<![CDATA[<script>alert(1)</script>]]>`,
+	`<img src=x:alert(alt) onerror=eval(src) alt=0>`,
+	`"><script>alert(1)</script>`,
+	`'><img src=x onerror=alert(1)>`,
+	`<p style="behavior:url(#default#VML)">x</p>`,
+	`<a href="http://example.com" onclick="alert(1)" ONCLICK="alert(2)">x</a>`,
+	`<img src="https://example.com/a.png" srcset="javascript:alert(1) 2x">`,
+}
+
+func TestSanitizeHTMLXSSVectors(t *testing.T) {
+	t.Parallel()
+
+	for _, policy := range []HTMLPolicy{StrictHTMLPolicy(), UGCHTMLPolicy()} {
+		for _, vector := range xssVectors {
+			got := SanitizeHTML(vector, policy)
+			requireSanitized(t, got, policy)
+			require.Equal(t, got, SanitizeHTML(got, policy), "not idempotent for %q", vector)
+		}
+	}
+}
+
+func FuzzSanitizeHTML(f *testing.F) {
+	for _, vector := range xssVectors[:10] {
+		f.Add(vector)
+	}
+	f.Add(`<a href="/x"><b>y</a>`)
+	f.Fuzz(func(t *testing.T, input string) {
+		policy := UGCHTMLPolicy()
+		got := SanitizeHTML(input, policy)
+		requireSanitized(t, got, policy)
+	})
+}
+
+// requireSanitized re-tokenizes sanitized output and checks that every
+// element, attribute, and URL in it is one the policy allows.
+func requireSanitized(t *testing.T, out string, policy HTMLPolicy) {
+	t.Helper()
+
+	s := newHTMLSanitizer(policy)
+	lower := strings.ToLower(out)
+	for _, bad := range []string{"<script", "<style", "<iframe", "<svg", "<object", "<embed", "<meta", "<base", "<link", "<!--"} {
+		require.NotContains(t, lower, bad, "output %q", out)
+	}
+	z := html.NewTokenizer(strings.NewReader(out))
+	for {
+		tt := z.Next()
+		switch tt {
+		case html.ErrorToken:
+			return
+		case html.StartTagToken, html.SelfClosingTagToken, html.EndTagToken:
+			tok := z.Token()
+			require.True(t, s.elements[tok.Data], "element %q in %q", tok.Data, out)
+			for _, a := range tok.Attr {
+				require.False(t, strings.HasPrefix(a.Key, "on"), "handler %q in %q", a.Key, out)
+				allowed := s.attributes[tok.Data][a.Key] || s.attributes["*"][a.Key] || (a.Key == "rel" && policy.AddRelNoFollow)
+				require.True(t, allowed, "attribute %q on %q in %q", a.Key, tok.Data, out)
+				if sanitizeURLAttrs[a.Key] {
+					require.True(t, s.allowedURL(a.Val), "URL %q in %q", a.Val, out)
+				}
+			}
+		case html.CommentToken, html.DoctypeToken:
+			t.Fatalf("comment or doctype in %q", out)
+		}
+	}
+}
@@ -0,0 +1,217 @@
+package filter
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// ToYAML encodes input as a YAML document with two-space indentation,
+// following gopkg.in/yaml.v3 rules: struct fields use their yaml tags, or
+// the lower-cased field name, and map keys are sorted, so equal input
+// always gives the same output. json.Number values inside maps and slices
+// from ParseJSON are written as numbers. The result ends with a line break.
+//
+// A value YAML cannot represent, such as a channel or a function, returns
+// *Error{Kind: KindInvalidInput}.
+func ToYAML(input any) (out string, err error) {
+	defer func() {
+		// yaml.v3 panics instead of returning an error for some types.
+		if r := recover(); r != nil {
+			out, err = "", invalidInput("ToYAML", fmt.Errorf("%v", r))
+		}
+	}()
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(yamlNumbers(input)); err != nil {
+		return "", invalidInput("ToYAML", err)
+	}
+	if err := enc.Close(); err != nil {
+		return "", invalidInput("ToYAML", err)
+	}
+	return buf.String(), nil
+}
+
+// yamlNumbers replaces json.Number values in the map[string]any and []any
+// trees ParseJSON returns with YAML number nodes, which keep their exact
+// digits. Integers beyond int64 are tagged as floats so ParseYAML can read
+// them back. Other values are returned as they are.
+func yamlNumbers(v any) any {
+	switch v := v.(type) {
+	case json.Number:
+		tag := "!!float"
+		if _, err := v.Int64(); err == nil {
+			tag = "!!int"
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: string(v)}
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, item := range v {
+			out[k] = yamlNumbers(item)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			out[i] = yamlNumbers(item)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// ParseYAML decodes a single YAML document. Mappings with string keys
+// become map[string]any, other mappings map[any]any, sequences []any, and
+// scalars string, int, float64, bool, or nil following YAML 1.2 core
+// schema resolution. Empty input decodes to nil.
+//
+// Invalid YAML, or more than one document, returns
+// *Error{Kind: KindFormat} wrapping the parser error, which names the line.
+func ParseYAML(input string) (any, error) {
+	dec := yaml.NewDecoder(strings.NewReader(input))
+	var out any
+	if err := dec.Decode(&out); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil
+		}
+		return nil, formatErr("ParseYAML", err)
+	}
+	var extra any
+	if err := dec.Decode(&extra); !errors.Is(err, io.EOF) {
+		if err == nil {
+			err = errors.New("more than one document")
+		}
+		return nil, formatErr("ParseYAML", err)
+	}
+	return out, nil
+}
+
+// ToTOML encodes input, which must be a map or struct, as a TOML document,
+// following github.com/BurntSushi/toml rules: struct fields use their toml
+// tags, map keys are sorted, nested maps become [table] sections without
+// indentation, and nil values are left out because TOML has no null.
+//
+// Input that is not a map or struct, or holds a value TOML cannot
+// represent, such as a channel, returns *Error{Kind: KindInvalidInput}.
+func ToTOML(input any) (string, error) {
+	v := reflect.Indirect(reflect.ValueOf(input))
+	if v.Kind() != reflect.Map && v.Kind() != reflect.Struct {
+		return "", invalidInput("ToTOML", fmt.Errorf("expected map or struct, got %T", input))
+	}
+	var buf bytes.Buffer
+	enc := toml.NewEncoder(&buf)
+	enc.Indent = ""
+	if err := enc.Encode(input); err != nil {
+		return "", invalidInput("ToTOML", err)
+	}
+	return buf.String(), nil
+}
+
+// ToCSV writes records as CSV with one column per entry of columns. Each
+// column is a key path, as accepted by Extract, looked up in every record;
+// the first line is a header of the paths themselves. Missing and nil
+// values give empty cells, and other values are formatted with fmt.Sprint.
+// Every cell, header included, is written with CSVEscape, so fields are
+// quoted as RFC 4180 requires and guarded against formula injection.
+// Lines end with "\n".
+//
+// records must be a slice or array and columns must not be empty;
+// otherwise, or if a column is not a valid path, ToCSV returns
+// *Error{Kind: KindInvalidInput}.
+func ToCSV(records any, columns []string) (string, error) {
+	slice, err := toSlice(records)
+	if err != nil {
+		return "", invalidInput("ToCSV", err)
+	}
+	if len(columns) == 0 {
+		return "", invalidInput("ToCSV", errors.New("no columns"))
+	}
+	keys := make([]lookupKey, len(columns))
+	for i, column := range columns {
+		keys[i] = newLookupKey(column)
+		if keys[i].err != nil {
+			return "", invalidInputAt("ToCSV", column, keys[i].err)
+		}
+	}
+
+	var b strings.Builder
+	writeCSVLine(&b, columns)
+	cells := make([]string, len(keys))
+	for _, record := range slice {
+		for i, key := range keys {
+			cells[i] = ""
+			if v, ok := lookupValue(record, key); ok && v != nil {
+				cells[i] = fmt.Sprint(v)
+			}
+		}
+		writeCSVLine(&b, cells)
+	}
+	return b.String(), nil
+}
+
+func writeCSVLine(b *strings.Builder, cells []string) {
+	for i, cell := range cells {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(CSVEscape(cell))
+	}
+	b.WriteByte('\n')
+}
+
+// ParseCSV reads RFC 4180 CSV whose first line is a header and returns one
+// map per following line, from header name to field. Fields are kept as
+// strings; a leading "'" added by CSVEscape is not removed. Empty input
+// returns an empty slice.
+//
+// A repeated header name returns *Error{Kind: KindFormat}, as does a line
+// with a different number of fields than the header or a bare quote, in
+// which case the error wraps the *csv.ParseError naming the line and
+// column.
+func ParseCSV(input string) ([]map[string]any, error) {
+	r := csv.NewReader(strings.NewReader(input))
+	header, err := r.Read()
+	if errors.Is(err, io.EOF) {
+		return []map[string]any{}, nil
+	}
+	if err != nil {
+		return nil, formatErr("ParseCSV", err)
+	}
+	seen := make(map[string]bool, len(header))
+	for _, name := range header {
+		if seen[name] {
+			return nil, formatErr("ParseCSV", fmt.Errorf("duplicate header %q", name))
+		}
+		seen[name] = true
+	}
+
+	var out []map[string]any
+	for {
+		fields, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, formatErr("ParseCSV", err)
+		}
+		row := make(map[string]any, len(header))
+		for i, name := range header {
+			row[name] = fields[i]
+		}
+		out = append(out, row)
+	}
+	if out == nil {
+		out = []map[string]any{}
+	}
+	return out, nil
+}
@@ -0,0 +1,367 @@
+package filter
+
+import (
+	"encoding/csv"
+	"errors"
+	"maps"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestToYAML(t *testing.T) {
+	t.Parallel()
+
+	type server struct {
+		Host  string   `yaml:"host"`
+		Port  int      `yaml:"port"`
+		Tags  []string `yaml:"tags,omitempty"`
+		Debug bool
+	}
+
+	tests := []struct {
+		name  string
+		input any
+		want  string
+	}{
+		{"Sorted Map Keys", map[string]any{"b": 2, "a": "x", "c": nil}, "a: x\nb: 2\nc: null\n"},
+		{"Nested", map[string]any{"db": map[string]any{"port": 5432}, "tags": []string{"a", "b"}}, "db:\n  port: 5432\ntags:\n  - a\n  - b\n"},
+		{"Struct Tags", server{Host: "localhost", Port: 80, Debug: true}, "host: localhost\nport: 80\ndebug: true\n"},
+		{"Ambiguous String Quoted", map[string]any{"v": "true", "n": "123"}, "\"n\": \"123\"\nv: \"true\"\n"},
+		{"Scalar", "hello", "hello\n"},
+		{"Nil", nil, "null\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ToYAML(tt.input)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestToYAMLJSONNumbers(t *testing.T) {
+	t.Parallel()
+
+	value, err := ParseJSON(`{"a": [1, 2.5, -3e2, 123456789012345678901234]}`)
+	require.NoError(t, err)
+	got, err := ToYAML(value)
+	require.NoError(t, err)
+	require.Equal(t, "a:\n  - 1\n  - 2.5\n  - -3e2\n  - 123456789012345678901234\n", got)
+
+	back, err := ParseYAML(got)
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"a": []any{1, 2.5, -300.0, 1.2345678901234568e+23}}, back)
+}
+
+func TestToYAMLUnsupported(t *testing.T) {
+	t.Parallel()
+
+	for _, input := range []any{make(chan int), map[string]any{"f": func() {}}} {
+		_, err := ToYAML(input)
+		require.ErrorIs(t, err, ErrInvalidInput, "input %#v", input)
+	}
+}
+
+func TestParseYAML(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input string
+		want  any
+	}{
+		{"Mapping", "name: Ada\nage: 36\nadmin: true\n", map[string]any{"name": "Ada", "age": 36, "admin": true}},
+		{"Sequence", "- 1.5\n- x\n- null\n", []any{1.5, "x", nil}},
+		{"Nested Flow", "a: {b: [1, 2]}", map[string]any{"a": map[string]any{"b": []any{1, 2}}}},
+		{"Non-String Keys", "1: one\n", map[any]any{1: "one"}},
+		{"Quoted Number Stays String", `v: "123"`, map[string]any{"v": "123"}},
+		{"YAML 1.2 Booleans", "v: yes", map[string]any{"v": "yes"}},
+		{"Document Marker", "---\na: 1\n", map[string]any{"a": 1}},
+		{"Empty", "", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseYAML(tt.input)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseYAMLInvalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input string
+		cause string
+	}{
+		{"Unclosed Flow Sequence", "a: [1\n", "line 1"},
+		{"Tab Indentation", "\tfoo: bar", "cannot start any token"},
+		{"Bad Indentation", "a:\n  b: 1\n c: 2\n", "line 2"},
+		{"Several Documents", "a: 1\n---\nb: 2\n", "more than one document"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := ParseYAML(tt.input)
+			require.ErrorIs(t, err, ErrFormat)
+			require.ErrorContains(t, err, tt.cause)
+		})
+	}
+}
+
+func TestToTOML(t *testing.T) {
+	t.Parallel()
+
+	type database struct {
+		Host string `toml:"host"`
+		Port int    `toml:"port"`
+	}
+	type config struct {
+		Title    string   `toml:"title"`
+		Owners   []string `toml:"owners"`
+		Database database `toml:"database"`
+	}
+
+	tests := []struct {
+		name  string
+		input any
+		want  string
+	}{
+		{
+			"Map With Table",
+			map[string]any{"title": "app", "debug": false, "db": map[string]any{"port": 5432, "host": "x"}, "skipped": nil},
+			"debug = false\ntitle = \"app\"\n\n[db]\nhost = \"x\"\nport = 5432\n",
+		},
+		{
+			"Struct Tags",
+			config{Title: "app", Owners: []string{"a", "b"}, Database: database{Host: "x", Port: 1}},
+			"title = \"app\"\nowners = [\"a\", \"b\"]\n\n[database]\nhost = \"x\"\nport = 1\n",
+		},
+		{
+			"Pointer To Struct",
+			&database{Host: "x", Port: 1},
+			"host = \"x\"\nport = 1\n",
+		},
+		{
+			"Array Of Tables",
+			map[string]any{"servers": []map[string]any{{"name": "a"}, {"name": "b"}}},
+			"[[servers]]\nname = \"a\"\n\n[[servers]]\nname = \"b\"\n",
+		},
+		{"Empty Map", map[string]any{}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ToTOML(tt.input)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestToTOMLInvalid(t *testing.T) {
+	t.Parallel()
+
+	for _, input := range []any{nil, "text", []int{1}, map[string]any{"c": make(chan int)}} {
+		_, err := ToTOML(input)
+		require.ErrorIs(t, err, ErrInvalidInput, "input %#v", input)
+	}
+}
+
+func TestToCSV(t *testing.T) {
+	t.Parallel()
+
+	type user struct {
+		Name  string `json:"name"`
+		Email string `json:"email"`
+	}
+
+	tests := []struct {
+		name    string
+		records any
+		columns []string
+		want    string
+	}{
+		{
+			"Maps With Nested Paths",
+			[]map[string]any{
+				{"id": 1, "user": map[string]any{"name": "Ada"}, "tags": []string{"x", "y"}},
+				{"id": 2, "user": map[string]any{"name": "Lin, Jr."}},
+			},
+			[]string{"id", "user.name", "tags.0"},
+			"id,user.name,tags.0\n1,Ada,x\n2,\"Lin, Jr.\",\n",
+		},
+		{
+			"Structs",
+			[]user{{Name: "Ada", Email: "ada@example.com"}},
+			[]string{"email", "name"},
+			"email,name\nada@example.com,Ada\n",
+		},
+		{
+			"Missing And Nil Cells",
+			[]any{map[string]any{"a": nil}, "scalar"},
+			[]string{"a", "b"},
+			"a,b\n,\n,\n",
+		},
+		{
+			"Formula Guarded",
+			[]map[string]any{{"v": "=HYPERLINK(\"x\")"}, {"v": -5}},
+			[]string{"v"},
+			"v\n\"'=HYPERLINK(\"\"x\"\")\"\n-5\n",
+		},
+		{
+			"Quotes And Line Breaks",
+			[]map[string]any{{"v": "say \"hi\"\nbye"}},
+			[]string{"v"},
+			"v\n\"say \"\"hi\"\"\nbye\"\n",
+		},
+		{"No Records", []any{}, []string{"a"}, "a\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ToCSV(tt.records, tt.columns)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestToCSVInvalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := ToCSV("not a slice", []string{"a"})
+	require.ErrorIs(t, err, ErrInvalidInput)
+
+	_, err = ToCSV([]any{}, nil)
+	require.ErrorIs(t, err, ErrInvalidInput)
+
+	_, err = ToCSV([]any{}, []string{`bad\path`})
+	require.ErrorIs(t, err, ErrInvalidInput)
+}
+
+func TestParseCSV(t *testing.T) {
+	t.Parallel()
+
+	got, err := ParseCSV("name,note\nAda,\"likes, commas\"\nLin,\"two\nlines\"\n")
+	require.NoError(t, err)
+	require.Equal(t, []map[string]any{
+		{"name": "Ada", "note": "likes, commas"},
+		{"name": "Lin", "note": "two\nlines"},
+	}, got)
+
+	got, err = ParseCSV("a,b\n")
+	require.NoError(t, err)
+	require.Empty(t, got)
+	require.NotNil(t, got)
+
+	got, err = ParseCSV("")
+	require.NoError(t, err)
+	require.Empty(t, got)
+	require.NotNil(t, got)
+}
+
+func TestParseCSVInvalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := ParseCSV("a,b\n1,2\n3\n")
+	require.ErrorIs(t, err, ErrFormat)
+	var parseErr *csv.ParseError
+	require.ErrorAs(t, err, &parseErr)
+	require.Equal(t, 3, parseErr.Line)
+	require.ErrorIs(t, err, csv.ErrFieldCount)
+
+	_, err = ParseCSV("a,b\n1,\"2\n")
+	require.ErrorIs(t, err, ErrFormat)
+	require.ErrorIs(t, err, csv.ErrQuote)
+
+	_, err = ParseCSV("a,a\n1,2\n")
+	require.ErrorIs(t, err, ErrFormat)
+}
+
+func TestCSVRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	records := []map[string]any{
+		{"name": "Ada", "city": "London, UK", "note": "said \"hi\""},
+		{"name": "Lin", "city": "", "note": " padded "},
+	}
+	out, err := ToCSV(records, []string{"name", "city", "note"})
+	require.NoError(t, err)
+	back, err := ParseCSV(out)
+	require.NoError(t, err)
+	require.Equal(t, []map[string]any{
+		{"name": "Ada", "city": "London, UK", "note": "said \"hi\""},
+		{"name": "Lin", "city": "", "note": " padded "},
+	}, back)
+}
+
+func FuzzParseYAML(f *testing.F) {
+	f.Add("a: 1\nb: [x, 2.5]\n")
+	f.Add("- &a x\n- *a\n")
+	f.Add("a: [1\n")
+	f.Fuzz(func(t *testing.T, input string) {
+		v, err := ParseYAML(input)
+		if err != nil {
+			if !errors.Is(err, ErrFormat) {
+				t.Fatalf("ParseYAML(%q) error %v is not KindFormat", input, err)
+			}
+			return
+		}
+		out, err := ToYAML(v)
+		if err != nil {
+			t.Fatalf("ToYAML(ParseYAML(%q)): %v", input, err)
+		}
+		if _, err := ParseYAML(out); err != nil {
+			t.Fatalf("ParseYAML(%q) from %q: %v", out, input, err)
+		}
+	})
+}
+
+func FuzzCSVRoundTrip(f *testing.F) {
+	f.Add("Ada", "London, UK")
+	f.Add("=1+1", "say \"hi\"\n")
+	f.Add("", " x ")
+	f.Fuzz(func(t *testing.T, a, b string) {
+		// csv.Reader turns \r\n inside quoted fields into \n.
+		if strings.Contains(a+b, "\r") {
+			return
+		}
+		out, err := ToCSV([]map[string]any{{"a": a, "b": b}}, []string{"a", "b"})
+		if err != nil {
+			t.Fatalf("ToCSV(%q, %q): %v", a, b, err)
+		}
+		back, err := ParseCSV(out)
+		if err != nil {
+			t.Fatalf("ParseCSV(%q): %v", out, err)
+		}
+		want := map[string]any{"a": csvGuarded(a), "b": csvGuarded(b)}
+		if len(back) != 1 || !maps.Equal(back[0], want) {
+			t.Fatalf("ParseCSV(ToCSV(%q, %q)) = %q, want [%q]", a, b, back, want)
+		}
+	})
+}
+
+// csvGuarded returns the field value CSVEscape stores for s.
+func csvGuarded(s string) string {
+	if s != "" && (s[0] == '=' || s[0] == '+' || s[0] == '-' || s[0] == '@' || s[0] == '\t') && !isSignedNumber(s) {
+		return "'" + s
+	}
+	return s
+}
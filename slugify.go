@@ -0,0 +1,213 @@
+package filter
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gosimple/unidecode"
+)
+
+// SlugOptions configures SlugifyWithOptions. The zero value behaves exactly
+// like Slugify.
+type SlugOptions struct {
+	// Separator joins the words of the slug. Empty means "-". Underscores in
+	// the input are kept, as in Slugify, unless Separator is "_", in which
+	// case they separate words like any other punctuation.
+	Separator string
+	// PreserveCase keeps the letter case of the transliterated input
+	// instead of lowercasing it.
+	PreserveCase bool
+	// MaxLength caps the slug at that many runes, cutting after the last
+	// whole word that fits. When the first word alone is longer, it is cut
+	// at exactly MaxLength. Zero or less means no limit.
+	MaxLength int
+	// Language selects language-specific transliteration by ISO 639 code,
+	// such as "de" for "ä" to "ae" or "fr" for "&" to "et". Empty means
+	// "en". See SlugifyWithOptions for the supported codes.
+	Language string
+	// Substitutions replaces substrings of the input before anything else,
+	// case-sensitively and in a single pass, preferring the longest match.
+	// The replacements are then transliterated and lowercased like the rest
+	// of the input, so {"C++": "cpp"} or {"&": "plus"} work as expected.
+	Substitutions map[string]string
+}
+
+// SlugifyWithOptions is Slugify with the separator, case, length limit,
+// transliteration language, and extra substitutions selected by opts. All
+// settings are per call; it never reads or changes package-level state.
+//
+// Language accepts these ISO 639-1 codes and their ISO 639-2 equivalents:
+// cs, da, de, en, es, fi, fr, hu, id, it, nb, nl, nn, no, pl, pt, ro, sl, sv,
+// and tr. Any other code returns *Error{Kind: KindInvalidInput}.
+func SlugifyWithOptions(input string, opts SlugOptions) (string, error) {
+	lang := cmp.Or(strings.ToLower(opts.Language), "en")
+	table, ok := slugLanguages[lang]
+	if !ok {
+		return "", invalidInput("SlugifyWithOptions", fmt.Errorf("unsupported language %q", opts.Language))
+	}
+	sep := cmp.Or(opts.Separator, "-")
+
+	s := strings.TrimSpace(input)
+	s = slugSubstitute(s, opts.Substitutions)
+	s = slugTransliterate(s, table)
+	s = unidecode.Unidecode(s)
+	if !opts.PreserveCase {
+		s = strings.ToLower(s)
+	}
+
+	keepUnderscore := sep != "_"
+	words := strings.FieldsFunc(s, func(r rune) bool {
+		return !(isASCIIAlnum(r) || (keepUnderscore && r == '_'))
+	})
+	words = trimSlugUnderscores(words)
+	if opts.MaxLength > 0 {
+		words = fitSlugWords(words, sep, opts.MaxLength)
+	}
+	return strings.Join(words, sep), nil
+}
+
+// slugSubstitute applies subs in one pass, longest key first so that a key
+// which is a prefix of another never shadows it.
+func slugSubstitute(s string, subs map[string]string) string {
+	if len(subs) == 0 {
+		return s
+	}
+	keys := make([]string, 0, len(subs))
+	for k := range subs {
+		if k != "" {
+			keys = append(keys, k)
+		}
+	}
+	slices.SortFunc(keys, func(a, b string) int {
+		return cmp.Or(cmp.Compare(len(b), len(a)), strings.Compare(a, b))
+	})
+	pairs := make([]string, 0, 2*len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, k, subs[k])
+	}
+	return strings.NewReplacer(pairs...).Replace(s)
+}
+
+func slugTransliterate(s string, table map[rune]string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	for _, r := range s {
+		if sub, ok := table[r]; ok {
+			b.WriteString(sub)
+		} else if sub, ok := slugPunctuation[r]; ok {
+			b.WriteString(sub)
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// trimSlugUnderscores drops underscores at either end of the slug, as
+// Slugify does.
+func trimSlugUnderscores(words []string) []string {
+	for len(words) > 0 {
+		words[0] = strings.TrimLeft(words[0], "_")
+		if words[0] != "" {
+			break
+		}
+		words = words[1:]
+	}
+	for len(words) > 0 {
+		last := len(words) - 1
+		words[last] = strings.TrimRight(words[last], "_")
+		if words[last] != "" {
+			break
+		}
+		words = words[:last]
+	}
+	return words
+}
+
+// fitSlugWords keeps the leading words that fit in maxLength runes once
+// joined with sep. Words are ASCII, so a too-long first word is cut by
+// bytes, and then trimmed so the slug still does not end with "_".
+func fitSlugWords(words []string, sep string, maxLength int) []string {
+	if len(words) == 0 {
+		return words
+	}
+	if len(words[0]) > maxLength {
+		// The cut can end on an underscore inside the word.
+		return trimSlugUnderscores([]string{words[0][:maxLength]})
+	}
+	used, sepLen := len(words[0]), utf8.RuneCountInString(sep)
+	for i, w := range words[1:] {
+		used += sepLen + len(w)
+		if used > maxLength {
+			return words[:i+1]
+		}
+	}
+	return words
+}
+
+func isASCIIAlnum(r rune) bool {
+	return 'a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || '0' <= r && r <= '9'
+}
+
+// slugPunctuation holds the substitutions Slugify applies in every
+// language: apostrophes and quotes vanish so "don't" stays one word, and
+// dashes become word breaks.
+var slugPunctuation = map[rune]string{
+	'"':  "",
+	'\'': "",
+	'’':  "",
+	'‒':  "-",
+	'–':  "-",
+	'—':  "-",
+	'―':  "-",
+}
+
+// slugLanguages maps language codes to the substitutions applied before
+// generic transliteration. They follow gosimple/slug's language tables,
+// leaving out letters that generic transliteration already maps the same
+// way.
+var slugLanguages = func() map[string]map[rune]string {
+	norwegian := map[rune]string{
+		'&': "og", '@': "at",
+		'æ': "ae", 'ø': "oe", 'å': "aa",
+		'Æ': "Ae", 'Ø': "Oe", 'Å': "Aa",
+	}
+	tables := map[string]map[rune]string{
+		"cs": {'&': "a", '@': "zavinac"},
+		"da": norwegian,
+		"de": {
+			'&': "und", '@': "an",
+			'ä': "ae", 'ö': "oe", 'ü': "ue",
+			'Ä': "Ae", 'Ö': "Oe", 'Ü': "Ue",
+		},
+		"en": {'&': "and", '@': "at"},
+		"es": {'&': "y", '@': "en"},
+		"fi": {'&': "ja", '@': "at"},
+		"fr": {'&': "et", '@': "arobase"},
+		"hu": {},
+		"id": {'&': "dan"},
+		"it": {'&': "e", '@': "chiocciola"},
+		"nb": norwegian,
+		"nl": {'&': "en", '@': "at"},
+		"nn": norwegian,
+		"no": norwegian,
+		"pl": {'&': "i", '@': "na"},
+		"pt": {'&': "e", '@': "em"},
+		"ro": {'&': "si"},
+		"sl": {'&': "in", 'Đ': "DZ", 'đ': "dz"},
+		"sv": {'&': "och", '@': "snabel a"},
+		"tr": {'&': "ve", '@': "et"},
+	}
+	for alias, code := range map[string]string{
+		"ces": "cs", "dan": "da", "deu": "de", "eng": "en", "spa": "es",
+		"fin": "fi", "fra": "fr", "hun": "hu", "ind": "id", "ita": "it",
+		"nob": "nb", "nld": "nl", "nno": "nn", "nor": "no", "pol": "pl",
+		"por": "pt", "ron": "ro", "slv": "sl", "swe": "sv", "tur": "tr",
+	} {
+		tables[alias] = tables[code]
+	}
+	return tables
+}()
@@ -0,0 +1,108 @@
+package filter
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlugifyWithOptionsZeroValueMatchesSlugify(t *testing.T) {
+	t.Parallel()
+
+	inputs := []string{
+		"", "   ", "Hello World!", "Hellö Wörld хелло ворлд", "影師", "This & that",
+		"don't stop — now", "_-_leading and trailing_", "snake_case_input",
+		"  C++ & Go @ home ", "a - _ - b", "Ünïcödé Çàsé", "100% pure",
+	}
+	for _, input := range inputs {
+		got, err := SlugifyWithOptions(input, SlugOptions{})
+		require.NoError(t, err)
+		require.Equal(t, Slugify(input), got, "input %q", input)
+	}
+}
+
+func TestSlugifyWithOptions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input string
+		opts  SlugOptions
+		want  string
+	}{
+		{"Underscore Separator", "Hello World", SlugOptions{Separator: "_"}, "hello_world"},
+		{"Underscore Separator Collapses Underscores", "snake__case - input_", SlugOptions{Separator: "_"}, "snake_case_input"},
+		{"Dash Separator Keeps Underscores", "snake_case input", SlugOptions{}, "snake_case-input"},
+		{"Dot Separator", "Release Notes 2.0", SlugOptions{Separator: "."}, "release.notes.2.0"},
+		{"Preserve Case", "Hello World", SlugOptions{PreserveCase: true}, "Hello-World"},
+		{"Preserve Case Transliterates", "Ærøskøbing Straße", SlugOptions{PreserveCase: true}, "AEroskobing-Strasse"},
+		{"Max Length On Word Boundary", "The quick brown fox", SlugOptions{MaxLength: 15}, "the-quick-brown"},
+		{"Max Length Drops Partial Word", "The quick brown fox", SlugOptions{MaxLength: 14}, "the-quick"},
+		{"Max Length Cuts Long First Word", "Supercalifragilistic word", SlugOptions{MaxLength: 5}, "super"},
+		{"Max Length Cut Drops Trailing Underscore", "snake_case_name", SlugOptions{Separator: "--", MaxLength: 6}, "snake"},
+		{"Max Length Cut Drops Underscore Run", "Große__Straße", SlugOptions{Separator: "--", MaxLength: 7}, "grosse"},
+		{"Max Length Counts Separator", "ab cd ef", SlugOptions{Separator: "--", MaxLength: 6}, "ab--cd"},
+		{"Max Length Larger Than Slug", "short", SlugOptions{MaxLength: 50}, "short"},
+		{"German", "Müller & Söhne", SlugOptions{Language: "de"}, "mueller-und-soehne"},
+		{"German Three Letter Code", "Müller", SlugOptions{Language: "deu"}, "mueller"},
+		{"Language Is Case Insensitive", "Müller", SlugOptions{Language: "DE"}, "mueller"},
+		{"English Default", "Müller & Söhne", SlugOptions{}, "muller-and-sohne"},
+		{"Norwegian", "Blåbær & Sjø", SlugOptions{Language: "nb"}, "blaabaer-og-sjoe"},
+		{"French", "Pierre & Marie @ Paris", SlugOptions{Language: "fr"}, "pierre-et-marie-arobase-paris"},
+		{"Substitution", "C++ & C#", SlugOptions{Substitutions: map[string]string{"C++": "cpp", "C#": "csharp"}}, "cpp-and-csharp"},
+		{"Substitution Before Language", "a & b", SlugOptions{Language: "de", Substitutions: map[string]string{"&": "plus"}}, "a-plus-b"},
+		{"Substitution Prefers Longest Key", "go gopher", SlugOptions{Substitutions: map[string]string{"go": "x", "gopher": "mascot"}}, "x-mascot"},
+		{"Substitution Is Case Sensitive", "Go go", SlugOptions{Substitutions: map[string]string{"go": "x"}}, "go-x"},
+		{"Substitution Output Is Transliterated", "x", SlugOptions{Substitutions: map[string]string{"x": "Ünïcode Ωmega"}}, "unicode-omega"},
+		{"Empty Substitution Key Ignored", "a b", SlugOptions{Substitutions: map[string]string{"": "z"}}, "a-b"},
+		{"Empty Input", "", SlugOptions{Separator: "_", MaxLength: 3}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := SlugifyWithOptions(tt.input, tt.opts)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSlugifyWithOptionsUnknownLanguage(t *testing.T) {
+	t.Parallel()
+
+	_, err := SlugifyWithOptions("Hello", SlugOptions{Language: "xx"})
+	require.ErrorIs(t, err, ErrInvalidInput)
+}
+
+func FuzzSlugifyWithOptions(f *testing.F) {
+	f.Add("Hellö Wörld & friends", "-", 10)
+	f.Add("_snake_case_", "_", 0)
+	f.Add("snake_case_name", "--", 6)
+	f.Add("影師 — 100%", "", 3)
+	f.Fuzz(func(t *testing.T, input, sep string, maxLength int) {
+		if !utf8.ValidString(input) {
+			return
+		}
+		got, err := SlugifyWithOptions(input, SlugOptions{})
+		if err != nil || got != Slugify(input) {
+			t.Fatalf("SlugifyWithOptions(%q) = %q, %v; Slugify = %q", input, got, err, Slugify(input))
+		}
+		got, err = SlugifyWithOptions(input, SlugOptions{Separator: sep, MaxLength: maxLength})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if maxLength > 0 && utf8.RuneCountInString(got) > maxLength {
+			t.Fatalf("SlugifyWithOptions(%q, %d) = %q exceeds limit", input, maxLength, got)
+		}
+		if sep != "_" && (strings.HasPrefix(got, "_") || strings.HasSuffix(got, "_")) {
+			t.Fatalf("SlugifyWithOptions(%q, %q, %d) = %q has an underscore at an end", input, sep, maxLength, got)
+		}
+		if sep == "" && strings.ContainsAny(got, " \t\n") {
+			t.Fatalf("SlugifyWithOptions(%q) = %q contains whitespace", input, got)
+		}
+	})
+}
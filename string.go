@@ -17,6 +17,12 @@ import (
 
 const defaultSpaces = "_ :-/"
 
+// caseWordSpaces separates words for the case converters added after
+// Dasherize, which also split on "." so "config.maxRetries" becomes
+// "config_max_retries". Titleize, Camelize, Pascalize, and Dasherize keep
+// defaultSpaces, so "v1.2" and "Mr." stay whole there.
+const caseWordSpaces = defaultSpaces + "."
+
 // Trim strips leading and trailing whitespace from a string.
 func Trim(input string) string {
 	return strings.TrimSpace(input)
@@ -118,7 +124,15 @@ func Capitalize(input string) string {
 
 // Titleize capitalizes the start of each part of the string.
 func Titleize(input string) string {
-	parts := toParts(input, defaultSpaces, true)
+	return TitleizeWithOptions(input, CaseOptions{})
+}
+
+// TitleizeWithOptions is Titleize with the acronym set taken from opts.
+// Acronyms keep their exact spelling, so "ios_app" with "iOS" becomes
+// "iOS App".
+func TitleizeWithOptions(input string, opts CaseOptions) string {
+	acronyms := opts.acronymSet()
+	parts := toParts(input, defaultSpaces, true, acronyms)
 	var b strings.Builder
 	b.Grow(len(input))
 
@@ -126,6 +140,10 @@ func Titleize(input string) string {
 		if i > 0 {
 			b.WriteByte(' ')
 		}
+		if acronym, ok := acronyms.lookup(part); ok {
+			b.WriteString(acronym)
+			continue
+		}
 		runes := []rune(part)
 		for j, r := range runes {
 			if j == 0 || runes[j-1] == '-' {
@@ -140,12 +158,39 @@ func Titleize(input string) string {
 
 // Camelize converts input to camelCase.
 func Camelize(input string) string {
-	parts := toParts(input, defaultSpaces, true)
+	return CamelizeWithOptions(input, CaseOptions{})
+}
+
+// CamelizeWithOptions is Camelize with the acronym set taken from opts. An
+// acronym that starts the output is lowercased, so "api_key" with "API"
+// becomes "apiKey".
+func CamelizeWithOptions(input string, opts CaseOptions) string {
+	return camelize(input, false, opts.acronymSet())
+}
+
+// Pascalize converts input to PascalCase.
+func Pascalize(input string) string {
+	return PascalizeWithOptions(input, CaseOptions{})
+}
+
+// PascalizeWithOptions is Pascalize with the acronym set taken from opts. A
+// leading acronym keeps its exact spelling, so "ios_app" with "iOS" becomes
+// "iOSApp".
+func PascalizeWithOptions(input string, opts CaseOptions) string {
+	return camelize(input, true, opts.acronymSet())
+}
+
+func camelize(input string, upperFirst bool, acronyms *acronymSet) string {
+	parts := toParts(input, defaultSpaces, true, acronyms)
 	var b strings.Builder
 	b.Grow(len(input))
 
 	for i, part := range parts {
-		if acronym, ok := baseAcronyms[strings.ToUpper(part)]; ok {
+		if acronym, ok := acronyms.lookup(part); ok {
+			if i == 0 && !upperFirst && acronyms.custom {
+				// camelCase starts lower case even with an acronym.
+				acronym = strings.ToLower(acronym)
+			}
 			b.WriteString(acronym)
 			continue
 		}
@@ -156,7 +201,7 @@ func Camelize(input string) string {
 				continue
 			}
 			if !capped {
-				if i == 0 {
+				if i == 0 && !upperFirst {
 					temp.WriteRune(unicode.ToLower(c))
 				} else {
 					temp.WriteRune(unicode.ToUpper(c))
@@ -173,39 +218,105 @@ func Camelize(input string) string {
 	return b.String()
 }
 
-// Pascalize converts input to PascalCase.
-func Pascalize(input string) string {
-	if input == "" {
-		return ""
-	}
-	camelized := Camelize(input)
-	r, size := utf8.DecodeRuneInString(camelized)
-	return string(unicode.ToUpper(r)) + camelized[size:]
-}
-
 // Dasherize converts input to lowercase words joined by dashes.
 func Dasherize(input string) string {
-	parts := toParts(input, defaultSpaces, true)
-	out := make([]string, 0, len(parts))
-	for _, part := range parts {
-		var b strings.Builder
-		b.Grow(len(part))
-		for _, c := range part {
-			if unicode.IsLetter(c) || unicode.IsDigit(c) {
-				b.WriteRune(unicode.ToLower(c))
-			}
+	return DasherizeWithOptions(input, CaseOptions{})
+}
+
+// DasherizeWithOptions is Dasherize with the acronym set taken from opts.
+// Acronyms only affect where words are split, as in "graphql-schema".
+func DasherizeWithOptions(input string, opts CaseOptions) string {
+	return joinCaseWords(input, defaultSpaces, "-", opts.acronymSet(), lowerWord)
+}
+
+// SnakeCase converts input to lowercase words joined by underscores, as in
+// "user_id". It splits words like Dasherize and Camelize, and also at ".".
+func SnakeCase(input string) string {
+	return SnakeCaseWithOptions(input, CaseOptions{})
+}
+
+// SnakeCaseWithOptions is SnakeCase with the acronym set taken from opts.
+func SnakeCaseWithOptions(input string, opts CaseOptions) string {
+	return joinCaseWords(input, caseWordSpaces, "_", opts.acronymSet(), lowerWord)
+}
+
+// ConstantCase converts input to uppercase words joined by underscores, as
+// in "USER_ID".
+func ConstantCase(input string) string {
+	return ConstantCaseWithOptions(input, CaseOptions{})
+}
+
+// ConstantCaseWithOptions is ConstantCase with the acronym set taken from
+// opts.
+func ConstantCaseWithOptions(input string, opts CaseOptions) string {
+	return joinCaseWords(input, caseWordSpaces, "_", opts.acronymSet(), upperWord)
+}
+
+// DotCase converts input to lowercase words joined by dots, as in "user.id".
+func DotCase(input string) string {
+	return DotCaseWithOptions(input, CaseOptions{})
+}
+
+// DotCaseWithOptions is DotCase with the acronym set taken from opts.
+func DotCaseWithOptions(input string, opts CaseOptions) string {
+	return joinCaseWords(input, caseWordSpaces, ".", opts.acronymSet(), lowerWord)
+}
+
+// PathCase converts input to lowercase words joined by slashes, as in
+// "user/id".
+func PathCase(input string) string {
+	return PathCaseWithOptions(input, CaseOptions{})
+}
+
+// PathCaseWithOptions is PathCase with the acronym set taken from opts.
+func PathCaseWithOptions(input string, opts CaseOptions) string {
+	return joinCaseWords(input, caseWordSpaces, "/", opts.acronymSet(), lowerWord)
+}
+
+// TrainCase converts input to capitalized words joined by dashes, as in
+// "User-ID". Known acronyms keep their canonical spelling.
+func TrainCase(input string) string {
+	return TrainCaseWithOptions(input, CaseOptions{})
+}
+
+// TrainCaseWithOptions is TrainCase with the acronym set taken from opts.
+func TrainCaseWithOptions(input string, opts CaseOptions) string {
+	acronyms := opts.acronymSet()
+	return joinCaseWords(input, caseWordSpaces, "-", acronyms, func(i int, word string) string {
+		if acronym, ok := acronyms.lookup(word); ok {
+			return acronym
 		}
-		if b.Len() > 0 {
-			out = append(out, b.String())
+		return Capitalize(word)
+	})
+}
+
+// SentenceCase converts input to space-separated words with only the first
+// word capitalized, as in "User profile ID". Known acronyms keep their
+// canonical spelling.
+func SentenceCase(input string) string {
+	return SentenceCaseWithOptions(input, CaseOptions{})
+}
+
+// SentenceCaseWithOptions is SentenceCase with the acronym set taken from
+// opts.
+func SentenceCaseWithOptions(input string, opts CaseOptions) string {
+	acronyms := opts.acronymSet()
+	return joinCaseWords(input, caseWordSpaces, " ", acronyms, func(i int, word string) string {
+		if acronym, ok := acronyms.lookup(word); ok {
+			return acronym
 		}
-	}
-	return strings.Join(out, "-")
+		if i == 0 {
+			return Capitalize(word)
+		}
+		return strings.ToLower(word)
+	})
 }
 
 // Slugify converts input to a URL-friendly slug.
 //
 // Note: Slugify is intentionally opinionated: transliteration and word
-// boundary choices are policy, not universal truth.
+// boundary choices are policy, not universal truth. Use SlugifyWithOptions
+// to choose them per call.
 func Slugify(input string) string {
 	return slug.Make(input)
 }
@@ -251,10 +362,7 @@ func Ordinalize(number int) string {
 
 // Truncate shortens input to maxLength runes. Default ellipsis is "...".
 func Truncate(input string, maxLength int, ellipsis ...string) string {
-	omission := "..."
-	if len(ellipsis) > 0 {
-		omission = ellipsis[0]
-	}
+	omission := truncateOmission(ellipsis)
 	if maxLength <= 0 {
 		return ""
 	}
@@ -269,52 +377,27 @@ func Truncate(input string, maxLength int, ellipsis ...string) string {
 	return string(runes[:maxLength-len(omissionRunes)]) + omission
 }
 
-// TruncateWords shortens input to maxWords words. Default ellipsis is "...".
+// TruncateWords shortens input to maxWords words, as WordCount counts
+// them, cutting right after the last kept word. Default ellipsis is "...".
 func TruncateWords(input string, maxWords int, ellipsis ...string) string {
-	omission := "..."
-	if len(ellipsis) > 0 {
-		omission = ellipsis[0]
-	}
+	omission := truncateOmission(ellipsis)
 	if maxWords <= 0 {
 		return ""
 	}
-
-	var words []string
-	var buf strings.Builder
-
-	appendWord := func() {
-		word := buf.String()
-		onlyBoundaries := true
-		for _, r := range word {
-			if !unicode.IsSpace(r) && !unicode.IsPunct(r) {
-				onlyBoundaries = false
-				break
-			}
-		}
-		if word != "" && !onlyBoundaries {
-			words = append(words, word)
-			buf.Reset()
-		}
-	}
-
-	for _, r := range input {
-		if unicode.IsSpace(r) || unicode.IsPunct(r) {
-			buf.WriteRune(r)
-			appendWord()
-		} else {
-			buf.WriteRune(r)
-		}
-	}
-	appendWord()
-
+	words := wordSpans(input)
 	if len(words) <= maxWords {
 		return input
 	}
-	truncated := strings.Join(words[:maxWords], "")
-	truncated = strings.TrimRightFunc(truncated, func(r rune) bool {
-		return unicode.IsSpace(r) || unicode.IsPunct(r)
-	})
-	return truncated + omission
+	return input[:words[maxWords-1].end] + omission
+}
+
+// truncateOmission returns the caller's ellipsis, or "..." when none was
+// given. An explicit empty string disables the ellipsis.
+func truncateOmission(ellipsis []string) string {
+	if len(ellipsis) > 0 {
+		return ellipsis[0]
+	}
+	return "..."
 }
 
 // Escape escapes HTML special characters in input.
@@ -343,8 +426,10 @@ var (
 // input on a best-effort basis.
 //
 // This is not a sanitizer and not a security boundary. For untrusted HTML,
-// use a real parser-based sanitizer (e.g. bluemonday). Provided here because
+// use SanitizeHTML with an explicit HTMLPolicy. Provided here because
 // it preserves the long-standing behavior of this package's StripHTML helper.
+// Use HTMLToText to keep paragraphs, list bullets, and link URLs and to
+// decode character references.
 func StripHTML(input string) string {
 	s := htmlScriptRe.ReplaceAllString(input, "")
 	s = htmlStyleRe.ReplaceAllString(s, "")
@@ -473,12 +558,38 @@ func sliceBounds(n, offset int, length ...int) (start, end int, ok bool) {
 	return offset, min(offset+size, n), true
 }
 
-func appendPart(parts []string, spaces, s string) []string {
+// joinCaseWords splits input with toParts on spaces, drops everything but
+// letters and digits from each word, and joins the transformed words with
+// sep. It is the shared core of the separator-based case converters, so
+// they all agree on where words begin and end.
+func joinCaseWords(input, spaces, sep string, acronyms *acronymSet, transform func(i int, word string) string) string {
+	parts := toParts(input, spaces, true, acronyms)
+	out := make([]string, 0, len(parts))
+	for _, part := range parts {
+		var b strings.Builder
+		b.Grow(len(part))
+		for _, c := range part {
+			if unicode.IsLetter(c) || unicode.IsDigit(c) {
+				b.WriteRune(c)
+			}
+		}
+		if b.Len() > 0 {
+			out = append(out, transform(len(out), b.String()))
+		}
+	}
+	return strings.Join(out, sep)
+}
+
+func lowerWord(_ int, word string) string { return strings.ToLower(word) }
+
+func upperWord(_ int, word string) string { return strings.ToUpper(word) }
+
+func appendPart(parts []string, spaces, s string, acronyms *acronymSet) []string {
 	s = strings.TrimSpace(s)
 	for _, r := range spaces {
 		s = strings.Trim(s, string(r))
 	}
-	if acronym, ok := baseAcronyms[strings.ToUpper(s)]; ok {
+	if acronym, ok := acronyms.lookup(s); ok {
 		s = acronym
 	}
 	if s != "" {
@@ -487,47 +598,69 @@ func appendPart(parts []string, spaces, s string) []string {
 	return parts
 }
 
-func toParts(s, spaces string, splitOnUpperCase bool) []string {
+// toParts splits s into words on the runes in spaces, on whitespace, and,
+// with splitOnUpperCase, where a lower-case rune is followed by an
+// upper-case one. With a set built from CaseOptions, an acronym that starts
+// a word is split out whole, so "GraphQLSchema" yields "GraphQL" and
+// "Schema" when "GraphQL" is in the set.
+func toParts(s, spaces string, splitOnUpperCase bool, acronyms *acronymSet) []string {
 	parts := []string{}
 	s = strings.TrimSpace(s)
 	if len(s) == 0 {
 		return parts
 	}
-	if _, ok := baseAcronyms[strings.ToUpper(s)]; ok {
-		return []string{strings.ToUpper(s)}
+	if acronym, ok := acronyms.lookup(s); ok {
+		return []string{acronym}
 	}
 	prev := rune(0)
+	wordStart := true
 	var x strings.Builder
 	x.Grow(len(s))
-	for _, c := range s {
+	for i := 0; i < len(s); {
+		c, size := utf8.DecodeRuneInString(s[i:])
+		if acronyms.custom && (wordStart || splitOnUpperCase && unicode.IsUpper(c) && !unicode.IsUpper(prev)) {
+			if n, acronym := acronyms.matchAt(s[i:]); n > 0 {
+				parts = appendPart(parts, spaces, x.String(), acronyms)
+				x.Reset()
+				parts = append(parts, acronym)
+				prev, _ = utf8.DecodeLastRuneInString(s[i : i+n])
+				wordStart = true
+				i += n
+				continue
+			}
+		}
+		i += size
 		if !utf8.ValidRune(c) {
 			continue
 		}
 		if strings.ContainsRune(spaces, c) || unicode.IsSpace(c) {
-			parts = appendPart(parts, spaces, x.String())
+			parts = appendPart(parts, spaces, x.String(), acronyms)
 			x.Reset()
 			x.WriteRune(c)
 			prev = c
+			wordStart = true
 			continue
 		}
 		if splitOnUpperCase && unicode.IsUpper(c) && !unicode.IsUpper(prev) && x.Len() > 0 {
-			parts = appendPart(parts, spaces, x.String())
+			parts = appendPart(parts, spaces, x.String(), acronyms)
 			x.Reset()
 		}
-		_, found := baseAcronyms[strings.ToUpper(x.String())]
-		if unicode.IsUpper(c) && found {
-			parts = appendPart(parts, spaces, x.String())
+		_, found := acronyms.lookup(x.String())
+		if splitOnUpperCase && unicode.IsUpper(c) && found {
+			parts = appendPart(parts, spaces, x.String(), acronyms)
 			x.Reset()
 		}
 		if unicode.IsLetter(c) || unicode.IsDigit(c) || unicode.IsPunct(c) || c == '`' {
 			prev = c
 			x.WriteRune(c)
+			wordStart = false
 			continue
 		}
-		parts = appendPart(parts, spaces, x.String())
+		parts = appendPart(parts, spaces, x.String(), acronyms)
 		x.Reset()
 		prev = c
+		wordStart = true
 	}
-	parts = appendPart(parts, spaces, x.String())
+	parts = appendPart(parts, spaces, x.String(), acronyms)
 	return parts
 }
@@ -283,6 +283,9 @@ func TestTitleize(t *testing.T) {
 		{"admin:settings", "Admin Settings"},
 		{"user_id", "User ID"},
 		{"user_name/profile:id", "User Name Profile ID"},
+		{"Mr. Smith", "Mr. Smith"},
+		{"v1.2 release", "V1.2 Release"},
+		{"e.g. this", "E.g. This"},
 	}
 
 	for _, tt := range tests {
@@ -388,6 +391,7 @@ func TestDasherize(t *testing.T) {
 		{"Contains Plus Character", "Phrase with + character", "phrase-with-character"},
 		{"Includes Malformed UTF8", "String with bad utf8 \250", "string-with-bad-utf8"},
 		{"Mixed Default Boundaries", "Mixed_Path:Name/ID", "mixed-path-name-id"},
+		{"Dots Do Not Split", "v1.2 release", "v12-release"},
 	}
 
 	for _, tt := range tests {
@@ -400,6 +404,168 @@ func TestDasherize(t *testing.T) {
 	}
 }
 
+func TestCaseConverters(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input    string
+		snake    string
+		constant string
+		dot      string
+		path     string
+		train    string
+		sentence string
+	}{
+		{"", "", "", "", "", "", ""},
+		{"hello world", "hello_world", "HELLO_WORLD", "hello.world", "hello/world", "Hello-World", "Hello world"},
+		{"helloWorld", "hello_world", "HELLO_WORLD", "hello.world", "hello/world", "Hello-World", "Hello world"},
+		{"UserAccounts", "user_accounts", "USER_ACCOUNTS", "user.accounts", "user/accounts", "User-Accounts", "User accounts"},
+		{"admin/AreaID", "admin_area_id", "ADMIN_AREA_ID", "admin.area.id", "admin/area/id", "Admin-Area-ID", "Admin area ID"},
+		{"user_id", "user_id", "USER_ID", "user.id", "user/id", "User-ID", "User ID"},
+		{"id_card", "id_card", "ID_CARD", "id.card", "id/card", "ID-Card", "ID card"},
+		{"HELLO_WORLD", "hello_world", "HELLO_WORLD", "hello.world", "hello/world", "Hello-World", "Hello world"},
+		{"version 2 update", "version_2_update", "VERSION_2_UPDATE", "version.2.update", "version/2/update", "Version-2-Update", "Version 2 update"},
+		{"Grace H. Hopper", "grace_h_hopper", "GRACE_H_HOPPER", "grace.h.hopper", "grace/h/hopper", "Grace-H-Hopper", "Grace h hopper"},
+		{"**Mixed_Path:Name/ID**", "mixed_path_name_id", "MIXED_PATH_NAME_ID", "mixed.path.name.id", "mixed/path/name/id", "Mixed-Path-Name-ID", "Mixed path name ID"},
+		{"résumé opération", "résumé_opération", "RÉSUMÉ_OPÉRATION", "résumé.opération", "résumé/opération", "Résumé-Opération", "Résumé opération"},
+		{"happy 😊 day", "happy_day", "HAPPY_DAY", "happy.day", "happy/day", "Happy-Day", "Happy day"},
+		{"config.maxRetries", "config_max_retries", "CONFIG_MAX_RETRIES", "config.max.retries", "config/max/retries", "Config-Max-Retries", "Config max retries"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tt.snake, SnakeCase(tt.input), "SnakeCase")
+			require.Equal(t, tt.constant, ConstantCase(tt.input), "ConstantCase")
+			require.Equal(t, tt.dot, DotCase(tt.input), "DotCase")
+			require.Equal(t, tt.path, PathCase(tt.input), "PathCase")
+			require.Equal(t, tt.train, TrainCase(tt.input), "TrainCase")
+			require.Equal(t, tt.sentence, SentenceCase(tt.input), "SentenceCase")
+		})
+	}
+}
+
+func TestCaseConvertersRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	converters := map[string]func(string) string{
+		"Camelize":     Camelize,
+		"Pascalize":    Pascalize,
+		"Dasherize":    Dasherize,
+		"Titleize":     Titleize,
+		"SnakeCase":    SnakeCase,
+		"ConstantCase": ConstantCase,
+		"DotCase":      DotCase,
+		"PathCase":     PathCase,
+		"TrainCase":    TrainCase,
+		"SentenceCase": SentenceCase,
+	}
+	// camelCase cannot mark a boundary before a digit, so every word here
+	// starts with a letter.
+	words := []string{"hello_world", "user_id", "id_card", "admin_area_id", "version2_update", "user_profile_id_card", "résumé_opération"}
+
+	for _, snake := range words {
+		for name, convert := range converters {
+			converted := convert(snake)
+			require.Equal(t, snake, SnakeCase(converted), "SnakeCase(%s(%q)) = SnakeCase(%q)", name, snake, converted)
+			require.Equal(t, DotCase(snake), DotCase(converted), "DotCase(%s(%q))", name, snake)
+		}
+	}
+}
+
+func TestCaseConvertersWithAcronyms(t *testing.T) {
+	t.Parallel()
+
+	domain := CaseOptions{Acronyms: []string{"SKU", "GraphQL", "OAuth", "iOS", "API"}}
+
+	tests := []struct {
+		name    string
+		convert func(string, CaseOptions) string
+		input   string
+		opts    CaseOptions
+		want    string
+	}{
+		{"Camelize Upper Acronym", CamelizeWithOptions, "product_sku", domain, "productSKU"},
+		{"Camelize Mixed Case Acronym In Camel Input", CamelizeWithOptions, "myGraphqlSchema", domain, "myGraphQLSchema"},
+		{"Camelize Splits Acronym From Following Word", CamelizeWithOptions, "GRAPHQLSchema", domain, "graphqlSchema"},
+		{"Camelize Adjacent Acronyms", CamelizeWithOptions, "myGraphqlAPIClient", domain, "myGraphQLAPIClient"},
+		{"Camelize Keeps Default ID", CamelizeWithOptions, "sku_id", domain, "skuID"},
+		{"Camelize Acronym Prefix Of Word", CamelizeWithOptions, "skulls_api", domain, "skullsAPI"},
+		{"Camelize Lowercases Leading Acronym", CamelizeWithOptions, "apiKey", domain, "apiKey"},
+		{"Camelize Lowercases Leading Mixed Case Acronym", CamelizeWithOptions, "oauth_token", domain, "oauthToken"},
+		{"Camelize Leading Upper Acronym Input", CamelizeWithOptions, "API key", domain, "apiKey"},
+		{"Pascalize Leading Mixed Case Acronym", PascalizeWithOptions, "ios_app", domain, "iOSApp"},
+		{"Pascalize OAuth", PascalizeWithOptions, "oauth token", domain, "OAuthToken"},
+		{"Titleize Mixed Case Acronym", TitleizeWithOptions, "ios_app", domain, "iOS App"},
+		{"Titleize Inside Camel Input", TitleizeWithOptions, "shopSkuList", domain, "Shop SKU List"},
+		{"Train Case", TrainCaseWithOptions, "oauth_token_id", domain, "OAuth-Token-ID"},
+		{"Sentence Case", SentenceCaseWithOptions, "graphqlSchemaForIos", domain, "GraphQL schema for iOS"},
+		{"Snake Case Splits On Acronym", SnakeCaseWithOptions, "GraphQLSchema", domain, "graphql_schema"},
+		{"Constant Case Splits On Acronym", ConstantCaseWithOptions, "iOSAppID", domain, "IOS_APP_ID"},
+		{"Dasherize Splits On Acronym", DasherizeWithOptions, "SKUList", domain, "sku-list"},
+		{"Replace Drops Defaults", TrainCaseWithOptions, "user_id", CaseOptions{Acronyms: []string{"SKU"}, ReplaceAcronyms: true}, "User-Id"},
+		{"Replace With Empty Set", CamelizeWithOptions, "id_card", CaseOptions{ReplaceAcronyms: true}, "idCard"},
+		{"Caller Overrides Default Spelling", TitleizeWithOptions, "user_id", CaseOptions{Acronyms: []string{"Id"}}, "User Id"},
+		{"Blank Entries Ignored", CamelizeWithOptions, "user_id", CaseOptions{Acronyms: []string{"", "  "}}, "userID"},
+		{"Zero Options Match Camelize", CamelizeWithOptions, "profile_id", CaseOptions{}, Camelize("profile_id")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tt.want, tt.convert(tt.input, tt.opts))
+		})
+	}
+}
+
+func TestCaseOptionsDoNotLeakBetweenCalls(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "iOSApp", PascalizeWithOptions("ios_app", CaseOptions{Acronyms: []string{"iOS"}}))
+	require.Equal(t, "IosApp", Pascalize("ios_app"))
+	require.Equal(t, "userID", Camelize("user_id"))
+}
+
+// TestCaseConvertersKeepBaselineWords pins the output of the converters
+// called without CaseOptions to what they returned before acronym sets were
+// added, since only a caller-provided set may change where words split.
+func TestCaseConvertersKeepBaselineWords(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input     string
+		titleize  string
+		camelize  string
+		pascalize string
+		dasherize string
+	}{
+		{"id'BAb", "Id' BAb", "idBAb", "IdBAb", "id-bab"},
+		{"isId''a", "Is Id''a", "isIda", "IsIda", "is-ida"},
+		{"user_id", "User ID", "userID", "UserID", "user-id"},
+		{"userIDList", "User ID List", "userIDList", "UserIDList", "user-id-list"},
+		{"HTTPServer", "HTTPServer", "hTTPServer", "HTTPServer", "httpserver"},
+		{"id", "ID", "ID", "ID", "id"},
+		{"identity_id", "Identity ID", "identityID", "IdentityID", "identity-id"},
+		{"my-ID'd", "My ID'd", "myIDd", "MyIDd", "my-idd"},
+		{"idBox", "ID Box", "IDBox", "IDBox", "id-box"},
+		{"id.card", "Id.card", "idcard", "Idcard", "idcard"},
+		{"ID`x", "ID`x", "iDx", "IDx", "idx"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tt.titleize, Titleize(tt.input))
+			require.Equal(t, tt.camelize, Camelize(tt.input))
+			require.Equal(t, tt.pascalize, Pascalize(tt.input))
+			require.Equal(t, tt.dasherize, Dasherize(tt.input))
+		})
+	}
+}
+
 func TestSlugify(t *testing.T) {
 	t.Parallel()
 
@@ -561,6 +727,10 @@ func TestTruncateWords(t *testing.T) {
 		{"Mixed Emoji Words", "😊 World 🌍 is beautiful 🏞️", 3, nil, "😊 World 🌍..."},
 		{"Custom Ellipsis", "Hello beautiful world", 2, []string{"--"}, "Hello beautiful--"},
 		{"Empty Ellipsis", "Hello beautiful world", 2, []string{""}, "Hello beautiful"},
+		{"Apostrophe Joins Words", "Don't stop believing", 2, nil, "Don't stop..."},
+		{"Hyphen Joins Words", "A well-known fact about cats", 3, nil, "A well-known fact..."},
+		{"Spaced Dash Separates Words", "Wait - what now", 2, nil, "Wait - what..."},
+		{"Leading Space Kept", "  Hello beautiful world", 1, nil, "  Hello..."},
 	}
 
 	for _, tt := range tests {
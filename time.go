@@ -1,6 +1,8 @@
 package filter
 
 import (
+	"encoding/json"
+	"fmt"
 	"math"
 	"strconv"
 	"strings"
@@ -142,10 +144,127 @@ func TimeAgoWithClock(clock Clock, input any) (string, error) {
 	return humanize.Relative(t, clock.Now()), nil
 }
 
+// Unix timestamps are accepted only when they resolve to a year in
+// [minUnixYear, maxUnixYear], the range Date's four-digit year token and
+// RFC 3339 can represent.
+const (
+	minUnixYear = 1
+	maxUnixYear = 9999
+)
+
+// Magnitude thresholds used by FromUnixAuto. A timestamp whose absolute value
+// is below autoUnixMilli is read as seconds, below autoUnixMicro as
+// milliseconds, below autoUnixNano as microseconds, and as nanoseconds
+// otherwise.
+const (
+	autoUnixMilli = 1e11
+	autoUnixMicro = 1e14
+	autoUnixNano  = 1e17
+)
+
+// FromUnixMilli converts a Unix timestamp in milliseconds to a UTC time.Time.
+//
+// input accepts integers, whole-number floats, and decimal integer strings.
+// Fractional input and timestamps outside years 1-9999 return
+// *Error{Kind: KindInvalidInput}.
+func FromUnixMilli(input any) (time.Time, error) {
+	v, err := toInt64Exact("FromUnixMilli", input)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return checkUnixRange("FromUnixMilli", time.UnixMilli(v).UTC())
+}
+
+// FromUnixMicro converts a Unix timestamp in microseconds to a UTC time.Time.
+// It follows the same input and range rules as FromUnixMilli.
+func FromUnixMicro(input any) (time.Time, error) {
+	v, err := toInt64Exact("FromUnixMicro", input)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return checkUnixRange("FromUnixMicro", time.UnixMicro(v).UTC())
+}
+
+// FromUnixNano converts a Unix timestamp in nanoseconds to a UTC time.Time.
+// It follows the same input and range rules as FromUnixMilli.
+func FromUnixNano(input any) (time.Time, error) {
+	v, err := toInt64Exact("FromUnixNano", input)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return checkUnixRange("FromUnixNano", time.Unix(0, v).UTC())
+}
+
+// FromUnixAuto converts a Unix timestamp to a UTC time.Time, inferring the
+// unit from its magnitude:
+//
+//	|v| < 1e11  seconds       (up to year 5138)
+//	|v| < 1e14  milliseconds
+//	|v| < 1e17  microseconds
+//	otherwise   nanoseconds
+//
+// Detection is a heuristic for loosely typed data such as JSON payloads that
+// mix JavaScript millisecond timestamps with Unix seconds. Callers that know
+// the unit should use the explicit FromUnix* variants instead.
+func FromUnixAuto(input any) (time.Time, error) {
+	v, err := toInt64Exact("FromUnixAuto", input)
+	if err != nil {
+		return time.Time{}, err
+	}
+	var t time.Time
+	switch abs := math.Abs(float64(v)); {
+	case abs < autoUnixMilli:
+		t = time.Unix(v, 0)
+	case abs < autoUnixMicro:
+		t = time.UnixMilli(v)
+	case abs < autoUnixNano:
+		t = time.UnixMicro(v)
+	default:
+		t = time.Unix(0, v)
+	}
+	return checkUnixRange("FromUnixAuto", t.UTC())
+}
+
+// ToUnix returns input as Unix seconds. input accepts everything Date
+// accepts; numeric input is already Unix seconds and must fall within
+// years 1-9999.
+func ToUnix(input any) (int64, error) {
+	t, err := toTime(input)
+	if err != nil {
+		return 0, err
+	}
+	return t.Unix(), nil
+}
+
+// ToUnixMilli returns input as Unix milliseconds. Instants whose millisecond
+// count overflows int64 return *Error{Kind: KindInvalidInput}.
+func ToUnixMilli(input any) (int64, error) {
+	t, err := toTime(input)
+	if err != nil {
+		return 0, err
+	}
+	sec := t.Unix()
+	if sec > math.MaxInt64/1000 || sec < math.MinInt64/1000 {
+		return 0, invalidInput("ToUnixMilli", fmt.Errorf("unix seconds %d overflow int64 milliseconds", sec))
+	}
+	return t.UnixMilli(), nil
+}
+
+func checkUnixRange(op string, t time.Time) (time.Time, error) {
+	if y := t.Year(); y < minUnixYear || y > maxUnixYear {
+		return time.Time{}, invalidInput(op, fmt.Errorf("timestamp resolves to year %d outside %d-%d", y, minUnixYear, maxUnixYear))
+	}
+	return t, nil
+}
+
 // toTime coerces input to time.Time (UTC by default).
 //
-// Accepts time.Time, gotime.Instant/DateTime/Date, int/int64 (Unix seconds),
-// float64 (Unix seconds), and strings parsed by gotime.Parse.
+// Accepts time.Time, gotime.Instant/DateTime/Date, signed and unsigned
+// integers (Unix seconds), floats (Unix seconds), json.Number (Unix
+// seconds), and strings parsed by gotime.Parse. Numeric input outside years
+// 1-9999 returns *Error{Kind: KindInvalidInput}. Use FromUnixMilli,
+// FromUnixMicro, FromUnixNano, or FromUnixAuto for timestamps in other
+// units.
 func toTime(input any) (time.Time, error) {
 	switch v := input.(type) {
 	case time.Time:
@@ -165,17 +284,36 @@ func toTime(input any) (time.Time, error) {
 		}
 		return v.Std(gotime.UTC), nil
 	case int:
-		return time.Unix(int64(v), 0).UTC(), nil
+		return timeFromUnix(int64(v), 0)
 	case int64:
-		return time.Unix(v, 0).UTC(), nil
+		return timeFromUnix(v, 0)
 	case int32:
-		return time.Unix(int64(v), 0).UTC(), nil
+		return timeFromUnix(int64(v), 0)
+	case int16:
+		return timeFromUnix(int64(v), 0)
+	case int8:
+		return timeFromUnix(int64(v), 0)
+	case uint, uint8, uint16, uint32, uint64:
+		sec, err := toInt64Exact("toTime", v)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return timeFromUnix(sec, 0)
 	case float64:
 		return timeFromUnixFloat(v)
 	case float32:
 		return timeFromUnixFloat(float64(v))
 	case string:
 		return parseTimeString(v)
+	case json.Number:
+		if sec, err := v.Int64(); err == nil {
+			return timeFromUnix(sec, 0)
+		}
+		f, err := v.Float64()
+		if err != nil {
+			return time.Time{}, formatErr("toTime", err)
+		}
+		return timeFromUnixFloat(f)
 	default:
 		return time.Time{}, invalidInput("toTime", nil)
 	}
@@ -189,7 +327,15 @@ func timeFromUnixFloat(f float64) (time.Time, error) {
 		return time.Time{}, invalidInput("toTime", nil)
 	}
 	sec, nsec := splitFloat(f)
-	return time.Unix(sec, nsec).UTC(), nil
+	return timeFromUnix(sec, nsec)
+}
+
+// timeFromUnix converts Unix seconds to a UTC time.Time. Like the
+// FromUnix* helpers it rejects timestamps outside years 1-9999, so a
+// millisecond timestamp passed where seconds are expected fails instead of
+// landing tens of thousands of years in the future.
+func timeFromUnix(sec, nsec int64) (time.Time, error) {
+	return checkUnixRange("toTime", time.Unix(sec, nsec).UTC())
 }
 
 func splitFloat(f float64) (sec, nsec int64) {
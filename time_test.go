@@ -1,6 +1,7 @@
 package filter
 
 import (
+	"encoding/json"
 	"errors"
 	"math"
 	"testing"
@@ -333,6 +334,193 @@ func TestDateRejectsOutOfRangeUnixFloatSeconds(t *testing.T) {
 	}
 }
 
+func TestDateAcceptsAllIntegerUnixSeconds(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input any
+		want  string
+	}{
+		{name: "int8", input: int8(60), want: "1970-01-01 00:01:00"},
+		{name: "int16", input: int16(3600), want: "1970-01-01 01:00:00"},
+		{name: "uint", input: uint(1711811045), want: "2024-03-30 15:04:05"},
+		{name: "uint8", input: uint8(1), want: "1970-01-01 00:00:01"},
+		{name: "uint16", input: uint16(60), want: "1970-01-01 00:01:00"},
+		{name: "uint32", input: uint32(1711811045), want: "2024-03-30 15:04:05"},
+		{name: "uint64", input: uint64(1711811045), want: "2024-03-30 15:04:05"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := Date(tt.input, "")
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNumericSecondsRejectMillisecondMagnitude(t *testing.T) {
+	t.Parallel()
+
+	// A JavaScript millisecond timestamp read as Unix seconds would land in
+	// year 56215.
+	for _, input := range []any{
+		int64(1711811045123),
+		1711811045123,
+		uint64(1711811045123),
+		float64(1711811045123),
+		json.Number("1711811045123"),
+		json.Number("1711811045123.5"),
+		int64(-1711811045123),
+	} {
+		_, err := Date(input, "Y-m-d")
+		require.ErrorIs(t, err, ErrInvalidInput, "Date(%#v)", input)
+		_, err = ToUnix(input)
+		require.ErrorIs(t, err, ErrInvalidInput, "ToUnix(%#v)", input)
+		_, err = ToUnixMilli(input)
+		require.ErrorIs(t, err, ErrInvalidInput, "ToUnixMilli(%#v)", input)
+	}
+
+	// The bounds are the first and last second of years 1 and 9999.
+	got, err := Date(int64(-62135596800), "Y-m-d H:i:s")
+	require.NoError(t, err)
+	require.Equal(t, "1-01-01 00:00:00", got)
+	got, err = Date(int64(253402300799), "Y-m-d H:i:s")
+	require.NoError(t, err)
+	require.Equal(t, "9999-12-31 23:59:59", got)
+	_, err = Date(int64(253402300800), "Y")
+	require.ErrorIs(t, err, ErrInvalidInput)
+
+	ms, err := ToUnixMilli(int64(1711811045))
+	require.NoError(t, err)
+	require.Equal(t, int64(1711811045000), ms)
+}
+
+func TestDateRejectsOverflowingUnsignedSeconds(t *testing.T) {
+	t.Parallel()
+
+	_, err := Date(uint64(math.MaxUint64), "Y")
+	require.ErrorIs(t, err, ErrInvalidInput)
+}
+
+func TestFromUnixUnits(t *testing.T) {
+	t.Parallel()
+
+	want := time.Date(2024, time.March, 30, 15, 4, 5, 123456789, time.UTC)
+
+	tests := []struct {
+		name string
+		fn   func(any) (time.Time, error)
+		in   any
+		want time.Time
+	}{
+		{"milli", FromUnixMilli, int64(1711811045123), want.Truncate(time.Millisecond)},
+		{"milli string", FromUnixMilli, "1711811045123", want.Truncate(time.Millisecond)},
+		{"milli whole float", FromUnixMilli, 1711811045123.0, want.Truncate(time.Millisecond)},
+		{"micro", FromUnixMicro, int64(1711811045123456), want.Truncate(time.Microsecond)},
+		{"nano", FromUnixNano, int64(1711811045123456789), want},
+		{"negative milli", FromUnixMilli, -1000, time.Date(1969, time.December, 31, 23, 59, 59, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tt.fn(tt.in)
+			require.NoError(t, err)
+			require.True(t, tt.want.Equal(got), "want %v, got %v", tt.want, got)
+			require.Equal(t, time.UTC, got.Location())
+		})
+	}
+}
+
+func TestFromUnixRejectsInvalidInput(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		fn   func(any) (time.Time, error)
+		in   any
+		want error
+	}{
+		{"milli beyond year 9999", FromUnixMilli, int64(math.MaxInt64), ErrInvalidInput},
+		{"milli before year 1", FromUnixMilli, int64(math.MinInt64), ErrInvalidInput},
+		{"micro beyond year 9999", FromUnixMicro, int64(math.MaxInt64 / 10), ErrInvalidInput},
+		{"nano overflowing uint64", FromUnixNano, uint64(math.MaxUint64), ErrInvalidInput},
+		{"fractional", FromUnixMilli, 1.5, ErrInvalidInput},
+		{"unsupported type", FromUnixMilli, struct{}{}, ErrInvalidInput},
+		{"unparseable string", FromUnixMicro, "soon", ErrFormat},
+		{"auto before year 1", FromUnixAuto, int64(-9e10), ErrInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := tt.fn(tt.in)
+			require.ErrorIs(t, err, tt.want)
+		})
+	}
+}
+
+func TestFromUnixAuto(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		in   any
+		want time.Time
+	}{
+		{"seconds", int64(1711811045), fixedDate},
+		{"milliseconds", int64(1711811045123), fixedDate.Add(123 * time.Millisecond)},
+		{"microseconds", int64(1711811045123456), fixedDate.Add(123456 * time.Microsecond)},
+		{"nanoseconds", int64(1711811045123456789), fixedDate.Add(123456789)},
+		{"negative seconds", -86400, time.Date(1969, time.December, 31, 0, 0, 0, 0, time.UTC)},
+		{"zero", 0, time.Unix(0, 0).UTC()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := FromUnixAuto(tt.in)
+			require.NoError(t, err)
+			require.True(t, tt.want.Equal(got), "want %v, got %v", tt.want, got)
+		})
+	}
+}
+
+func TestToUnix(t *testing.T) {
+	t.Parallel()
+
+	value := fixedDate.Add(123 * time.Millisecond)
+
+	sec, err := ToUnix(value)
+	require.NoError(t, err)
+	require.Equal(t, int64(1711811045), sec)
+
+	ms, err := ToUnixMilli(value)
+	require.NoError(t, err)
+	require.Equal(t, int64(1711811045123), ms)
+
+	back, err := FromUnixMilli(ms)
+	require.NoError(t, err)
+	require.True(t, value.Equal(back))
+}
+
+func TestToUnixRejectsInvalidInput(t *testing.T) {
+	t.Parallel()
+
+	_, err := ToUnix(struct{}{})
+	require.ErrorIs(t, err, ErrInvalidInput)
+
+	_, err = ToUnixMilli(int64(math.MaxInt64))
+	require.ErrorIs(t, err, ErrInvalidInput)
+}
+
 func FuzzDateFormat(f *testing.F) {
 	f.Add("Y-m-d H:i:s")
 	f.Add(`\Y\m\d`)
@@ -0,0 +1,192 @@
+package filter
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// TitleCaseStyle selects the style guide TitleCase follows.
+type TitleCaseStyle uint8
+
+const (
+	// TitleCaseAP follows the Associated Press: articles and conjunctions
+	// and prepositions of three letters or fewer ("and", "as", "of", "via")
+	// are lowercased.
+	TitleCaseAP TitleCaseStyle = iota
+	// TitleCaseChicago follows the Chicago Manual of Style: articles,
+	// coordinating conjunctions, and prepositions of any length are
+	// lowercased, and the part of a compound after a prefix such as "anti-"
+	// or "non-" is lowercased too.
+	TitleCaseChicago
+	// TitleCaseAPA follows the American Psychological Association: all
+	// words of four letters or more are capitalized, and every article,
+	// conjunction, and preposition of three letters or fewer ("as", "if",
+	// "per", "via") is lowercased.
+	TitleCaseAPA
+)
+
+// minorWords lists the lowercased words of each style.
+var minorWords = map[TitleCaseStyle]map[string]bool{
+	TitleCaseAP: wordSet(
+		"a", "an", "the",
+		"and", "but", "for", "nor", "or", "so", "yet",
+		"as", "at", "by", "in", "of", "on", "out", "to", "up", "via",
+	),
+	TitleCaseChicago: wordSet(
+		"a", "an", "the",
+		"and", "but", "for", "nor", "or",
+		"as", "to",
+		"about", "above", "across", "after", "against", "along", "amid",
+		"among", "around", "at", "before", "behind", "below", "beneath",
+		"beside", "between", "beyond", "by", "despite", "down", "during",
+		"except", "from", "in", "inside", "into", "like", "near", "of", "off",
+		"on", "onto", "out", "outside", "over", "past", "per", "since",
+		"through", "throughout", "till", "toward", "towards", "under",
+		"underneath", "until", "up", "upon", "via", "with", "within",
+		"without",
+	),
+	TitleCaseAPA: wordSet(
+		"a", "an", "the",
+		"and", "as", "but", "for", "if", "nor", "or", "so", "yet",
+		"at", "by", "in", "of", "off", "on", "per", "to", "up", "via",
+	),
+}
+
+// chicagoPrefixes cannot stand alone, so Chicago style lowercases the part
+// of a compound that follows them.
+var chicagoPrefixes = wordSet(
+	"anti", "co", "counter", "de", "extra", "inter", "intra", "mid", "multi",
+	"non", "post", "pre", "pro", "pseudo", "re", "semi", "sub", "super",
+	"trans", "ultra", "un",
+)
+
+func wordSet(words ...string) map[string]bool {
+	set := make(map[string]bool, len(words))
+	for _, w := range words {
+		set[w] = true
+	}
+	return set
+}
+
+// TitleCase converts input to headline case under the given style guide:
+//
+//   - Every word is capitalized except the style's minor words (articles,
+//     short conjunctions, and prepositions), which are lowercased unless they
+//     are the first or last word or follow a colon.
+//   - Each part of a hyphenated compound is treated as a word, so
+//     "state-of-the-art" becomes "State-of-the-Art".
+//   - Words already in all caps, such as "NASA", and words with interior
+//     capitals, such as "iPhone", are kept as written. When the whole input
+//     is in capitals it is treated as lower case instead.
+//
+// Words are separated by white space, and runs of it become one space.
+// Every other character, including symbols such as "+", "$", and emoji, is
+// kept as written. Unlike Titleize, TitleCase does not split words with
+// toParts: that splitting drops "_", ":", and "/" and breaks camelCase
+// apart, while a headline must keep its text and only change its case. So
+// "snake_case" stays one word here, and hyphens are handled as compounds
+// rather than separators. An unknown style is treated as TitleCaseAP.
+func TitleCase(input string, style TitleCaseStyle) string {
+	minor, ok := minorWords[style]
+	if !ok {
+		minor = minorWords[TitleCaseAP]
+	}
+	shouting := isAllCaps(input)
+	words := strings.Fields(input)
+
+	var b strings.Builder
+	b.Grow(len(input))
+	afterColon := false
+	for i, word := range words {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		// Only a word already in capitals is taken for an acronym, so the
+		// English word "id" keeps its case; in shouted input this restores
+		// "ID" where the rest is lowered.
+		if acronym, ok := defaultAcronyms.lookup(word); ok && isAllCaps(word) {
+			b.WriteString(acronym)
+			afterColon = false
+			continue
+		}
+		segments := strings.Split(word, "-")
+		for j, segment := range segments {
+			if j > 0 {
+				b.WriteByte('-')
+			}
+			major := i == 0 && j == 0 ||
+				i == len(words)-1 && j == len(segments)-1 ||
+				afterColon && j == 0
+			prefixed := style == TitleCaseChicago && j > 0 && chicagoPrefixes[strings.ToLower(segments[j-1])]
+			b.WriteString(titleCaseWord(segment, shouting, func(core string) bool {
+				return !prefixed && (major || !minor[strings.ToLower(core)])
+			}))
+		}
+		afterColon = strings.HasSuffix(word, ":")
+	}
+	return b.String()
+}
+
+// titleCaseWord cases one word or compound segment. capitalize reports
+// whether the word, stripped of surrounding punctuation, gets a capital.
+func titleCaseWord(word string, shouting bool, capitalize func(core string) bool) string {
+	start := strings.IndexFunc(word, isWordRune)
+	if start < 0 {
+		return word
+	}
+	end := strings.LastIndexFunc(word, isWordRune)
+	_, size := utf8.DecodeRuneInString(word[end:])
+	end += size
+	core := word[start:end]
+
+	if shouting {
+		core = strings.ToLower(core)
+	} else if keepsCase(core) {
+		return word
+	}
+	if capitalize(core) {
+		r, n := utf8.DecodeRuneInString(core)
+		core = string(unicode.ToTitle(r)) + core[n:]
+	} else {
+		core = strings.ToLower(core)
+	}
+	return word[:start] + core + word[end:]
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// keepsCase reports whether word is written in all caps or has a capital
+// after its first letter, so its casing is deliberate.
+func keepsCase(word string) bool {
+	letters, upper := 0, 0
+	interior := false
+	for i, r := range word {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		if unicode.IsUpper(r) {
+			upper++
+			if i > 0 {
+				interior = true
+			}
+		}
+	}
+	return letters > 1 && (upper == letters || interior)
+}
+
+// isAllCaps reports whether s has at least one letter and no lower-case
+// letters.
+func isAllCaps(s string) bool {
+	letters := false
+	for _, r := range s {
+		if unicode.IsLower(r) {
+			return false
+		}
+		letters = letters || unicode.IsLetter(r)
+	}
+	return letters
+}
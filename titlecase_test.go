@@ -0,0 +1,74 @@
+package filter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTitleCase(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input string
+		style TitleCaseStyle
+		want  string
+	}{
+		{"Empty", "", TitleCaseAP, ""},
+		{"Articles And Prepositions", "the lord of the rings", TitleCaseAP, "The Lord of the Rings"},
+		{"First And Last Minor Words", "a place to come from", TitleCaseChicago, "A Place to Come From"},
+		{"Capitalized Input Lowered", "The Lord Of The Rings", TitleCaseChicago, "The Lord of the Rings"},
+		{"Chicago Long Preposition", "a walk through the woods", TitleCaseChicago, "A Walk through the Woods"},
+		{"AP Long Preposition", "a walk through the woods", TitleCaseAP, "A Walk Through the Woods"},
+		{"APA Long Preposition", "a walk through the woods", TitleCaseAPA, "A Walk Through the Woods"},
+		{"APA Short Preposition", "life as a student via email", TitleCaseAPA, "Life as a Student via Email"},
+		{"AP Short Preposition", "life as a student via email", TitleCaseAP, "Life as a Student via Email"},
+		{"Chicago Capitalizes So", "small but mighty so far", TitleCaseChicago, "Small but Mighty So Far"},
+		{"Hyphenated Compound", "a state-of-the-art design", TitleCaseAPA, "A State-of-the-Art Design"},
+		{"Hyphenated Last Word", "how to build a self-report", TitleCaseAPA, "How to Build a Self-Report"},
+		{"Chicago Prefix Compound", "an anti-inflammatory diet", TitleCaseChicago, "An Anti-inflammatory Diet"},
+		{"AP Prefix Compound", "an anti-inflammatory diet", TitleCaseAP, "An Anti-Inflammatory Diet"},
+		{"After Colon", "star wars: a new hope", TitleCaseChicago, "Star Wars: A New Hope"},
+		{"All Caps Word Preserved", "the NASA guide to the galaxy", TitleCaseAP, "The NASA Guide to the Galaxy"},
+		{"Interior Capital Preserved", "tips for your iPhone and McDonald's", TitleCaseAP, "Tips for Your iPhone and McDonald's"},
+		{"Shouted Input", "THE LORD OF THE RINGS", TitleCaseAP, "The Lord of the Rings"},
+		{"Surrounding Punctuation", `"the end" of (an) era`, TitleCaseAP, `"The End" of (an) Era`},
+		{"Apostrophe", "don't stop believin'", TitleCaseAP, "Don't Stop Believin'"},
+		{"Collapses Whitespace", "  gone   with\tthe wind ", TitleCaseChicago, "Gone with the Wind"},
+		{"Underscores Kept", "the_lord_of_the_rings", TitleCaseAP, "The_lord_of_the_rings"},
+		{"Symbols Kept", "c++ tips & tricks", TitleCaseAP, "C++ Tips & Tricks"},
+		{"Currency Kept", "save $100 on shoes", TitleCaseAP, "Save $100 on Shoes"},
+		{"Lone Symbol Kept", "rock + roll", TitleCaseChicago, "Rock + Roll"},
+		{"Emoji Kept", "i ❤ go", TitleCaseAP, "I ❤ Go"},
+		{"Slash Kept", "input/output in practice", TitleCaseAP, "Input/output in Practice"},
+		{"Acronym In Capitals", "the user ID field", TitleCaseAP, "The User ID Field"},
+		{"Acronym In Shouted Input", "THE USER ID FIELD", TitleCaseAP, "The User ID Field"},
+		{"Ordinary Word Not An Acronym", "the id and the ego", TitleCaseAP, "The Id and the Ego"},
+		{"Non ASCII", "élan of the ñandú", TitleCaseAP, "Élan of the Ñandú"},
+		{"Unknown Style Falls Back To AP", "a walk through the woods", TitleCaseStyle(99), "A Walk Through the Woods"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tt.want, TitleCase(tt.input, tt.style))
+		})
+	}
+}
+
+func FuzzTitleCase(f *testing.F) {
+	f.Add("the lord of the rings", uint8(0))
+	f.Add("a state-of-the-art: NASA iPhone", uint8(1))
+	f.Fuzz(func(t *testing.T, input string, style uint8) {
+		got := TitleCase(input, TitleCaseStyle(style))
+		if want := strings.Join(strings.Fields(input), " "); !strings.EqualFold(got, want) {
+			t.Fatalf("TitleCase(%q) = %q drops or changes text other than case", input, got)
+		}
+		if again := TitleCase(got, TitleCaseStyle(style)); again != got && !isAllCaps(got) {
+			t.Fatalf("TitleCase is not idempotent: %q -> %q -> %q", input, got, again)
+		}
+	})
+}
@@ -0,0 +1,364 @@
+package filter
+
+import (
+	"html"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// TruncateOptions selects how TruncateWithOptions chooses its cut point.
+// The zero value behaves exactly like Truncate.
+type TruncateOptions struct {
+	// WordBoundary moves the cut back to the end of the last whole word
+	// that fits, so no word is split. When the first word alone is longer
+	// than the limit, the cut falls back to an exact rune count. Trailing
+	// whitespace and punctuation before the ellipsis are trimmed.
+	WordBoundary bool
+	// PreserveSeparator keeps punctuation that ends the kept text (for
+	// example the comma in "Hello,...") instead of trimming it. Trailing
+	// whitespace is still trimmed. It only applies with WordBoundary.
+	PreserveSeparator bool
+	// HTML treats input as an HTML fragment: only visible text counts
+	// toward maxLength, tags and the contents of script and style elements
+	// are never cut and cost nothing, a character reference such as "&amp;"
+	// counts as one rune and is never split, and elements left open at the
+	// cut are closed after the ellipsis.
+	HTML bool
+}
+
+// TruncateWithOptions shortens input to maxLength visible runes, including
+// the ellipsis, using the cut rules selected by opts. The ellipsis defaults
+// to "..." exactly as in Truncate; an explicit empty string disables it.
+//
+// The HTML mode is a formatting helper for trusted markup, not a sanitizer.
+func TruncateWithOptions(input string, maxLength int, opts TruncateOptions, ellipsis ...string) string {
+	omission := truncateOmission(ellipsis)
+	if maxLength <= 0 {
+		return ""
+	}
+
+	var units []truncateUnit
+	if opts.HTML {
+		units = htmlTruncateUnits(input)
+	} else {
+		units = textTruncateUnits(input)
+	}
+
+	visible := 0
+	for _, u := range units {
+		if u.visible() {
+			visible++
+		}
+	}
+	if visible <= maxLength {
+		return input
+	}
+
+	omissionRunes := []rune(omission)
+	if maxLength <= len(omissionRunes) {
+		return string(omissionRunes[:maxLength])
+	}
+
+	cut := truncateCut(units, maxLength-len(omissionRunes))
+	if opts.WordBoundary {
+		cut = wordBoundaryCut(units, cut, opts.PreserveSeparator)
+	}
+
+	var b strings.Builder
+	b.Grow(len(input))
+	var open []string
+	for _, u := range units[:cut] {
+		b.WriteString(u.text)
+		if opts.HTML {
+			open = trackOpenElement(open, u)
+		}
+	}
+	b.WriteString(omission)
+	for i := len(open) - 1; i >= 0; i-- {
+		b.WriteString("</")
+		b.WriteString(open[i])
+		b.WriteByte('>')
+	}
+	return b.String()
+}
+
+// truncateUnit is one indivisible piece of truncation input: either a
+// visible character (a rune, or a decoded HTML character reference) or an
+// invisible piece of markup.
+type truncateUnit struct {
+	text    string
+	r       rune   // visible character
+	markup  bool   // text is a tag or comment
+	tag     string // lowercased element name of a tag
+	closing bool   // markup is an end tag
+	void    bool   // markup needs no end tag
+}
+
+func (u truncateUnit) visible() bool { return !u.markup }
+
+func (u truncateUnit) boundary() bool {
+	return unicode.IsSpace(u.r) || unicode.IsPunct(u.r)
+}
+
+func textTruncateUnits(s string) []truncateUnit {
+	units := make([]truncateUnit, 0, len(s))
+	for i := 0; i < len(s); {
+		u, size := runeTruncateUnit(s[i:])
+		units = append(units, u)
+		i += size
+	}
+	return units
+}
+
+// runeTruncateUnit returns the unit for the rune at the start of s and its
+// byte length. An invalid byte becomes U+FFFD, as converting to []rune does
+// in Truncate.
+func runeTruncateUnit(s string) (truncateUnit, int) {
+	r, size := utf8.DecodeRuneInString(s)
+	text := s[:size]
+	if r == utf8.RuneError && size == 1 {
+		text = string(utf8.RuneError)
+	}
+	return truncateUnit{text: text, r: r}, size
+}
+
+// truncateCut returns the number of units that hold exactly budget visible
+// units, excluding any markup after the last one.
+func truncateCut(units []truncateUnit, budget int) int {
+	if budget == 0 {
+		return 0
+	}
+	seen := 0
+	for i, u := range units {
+		if !u.visible() {
+			continue
+		}
+		seen++
+		if seen == budget {
+			return i + 1
+		}
+	}
+	return len(units)
+}
+
+// wordBoundaryCut moves cut back so it does not split a word, then trims
+// trailing separators.
+func wordBoundaryCut(units []truncateUnit, cut int, preserveSeparator bool) int {
+	next := nextVisible(units, cut)
+	prev := prevVisible(units, cut)
+	if next >= 0 && prev >= 0 && !units[next].boundary() && !units[prev].boundary() {
+		for i := prev; i >= 0; i-- {
+			if units[i].visible() && units[i].boundary() {
+				cut = i + 1
+				break
+			}
+		}
+	}
+	for cut > 0 {
+		u := units[cut-1]
+		if u.visible() {
+			trim := unicode.IsSpace(u.r) || (!preserveSeparator && unicode.IsPunct(u.r))
+			if !trim {
+				break
+			}
+		}
+		cut--
+	}
+	return cut
+}
+
+func nextVisible(units []truncateUnit, from int) int {
+	for i := from; i < len(units); i++ {
+		if units[i].visible() {
+			return i
+		}
+	}
+	return -1
+}
+
+func prevVisible(units []truncateUnit, before int) int {
+	for i := before - 1; i >= 0; i-- {
+		if units[i].visible() {
+			return i
+		}
+	}
+	return -1
+}
+
+// htmlVoidElements never take an end tag.
+var htmlVoidElements = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true,
+	"hr": true, "img": true, "input": true, "link": true, "meta": true,
+	"source": true, "track": true, "wbr": true,
+}
+
+// htmlRawTextElements hold text that is not displayed and may contain "<"
+// and "&" freely, so their contents are markup, not visible text.
+var htmlRawTextElements = map[string]bool{"script": true, "style": true}
+
+// htmlTruncateUnits splits an HTML fragment into tags, comments, character
+// references, and plain runes. A "<" that does not start a tag is text. The
+// contents of a script or style element form one markup unit, so they cost
+// nothing and are never cut.
+func htmlTruncateUnits(s string) []truncateUnit {
+	units := make([]truncateUnit, 0, len(s))
+	for i := 0; i < len(s); {
+		switch s[i] {
+		case '<':
+			if n := htmlMarkupLen(s[i:]); n > 0 {
+				u := htmlMarkupUnit(s[i : i+n])
+				units = append(units, u)
+				i += n
+				if htmlRawTextElements[u.tag] && !u.closing && !u.void {
+					if m := htmlRawTextLen(s[i:], u.tag); m > 0 {
+						units = append(units, truncateUnit{text: s[i : i+m], markup: true})
+						i += m
+					}
+				}
+				continue
+			}
+		case '&':
+			if n := htmlEntityLen(s[i:]); n > 0 {
+				r, _ := utf8.DecodeRuneInString(html.UnescapeString(s[i : i+n]))
+				units = append(units, truncateUnit{text: s[i : i+n], r: r})
+				i += n
+				continue
+			}
+		}
+		u, size := runeTruncateUnit(s[i:])
+		units = append(units, u)
+		i += size
+	}
+	return units
+}
+
+// htmlRawTextLen returns the byte length of the contents of a raw text
+// element that starts s, up to its end tag or, when it has none, the end of
+// s.
+func htmlRawTextLen(s, tag string) int {
+	end := "</" + tag
+	for i := 0; i < len(s); i++ {
+		if s[i] != '<' || len(s)-i < len(end) || !strings.EqualFold(s[i:i+len(end)], end) {
+			continue
+		}
+		if rest := s[i+len(end):]; rest == "" || rest[0] == '>' || rest[0] == '/' || unicode.IsSpace(rune(rest[0])) {
+			return i
+		}
+	}
+	return len(s)
+}
+
+// htmlMarkupLen returns the byte length of the tag or comment at the start of
+// s, or 0 when s does not start with markup.
+func htmlMarkupLen(s string) int {
+	if strings.HasPrefix(s, "<!--") {
+		if end := strings.Index(s[4:], "-->"); end >= 0 {
+			return 4 + end + 3
+		}
+		return len(s)
+	}
+	if len(s) < 2 {
+		return 0
+	}
+	c := s[1]
+	if c == '/' && len(s) > 2 {
+		c = s[2]
+	}
+	if !isASCIILetter(c) && c != '!' {
+		return 0
+	}
+	var quote byte
+	for i := 1; i < len(s); i++ {
+		switch {
+		case quote != 0:
+			if s[i] == quote {
+				quote = 0
+			}
+		case s[i] == '"' || s[i] == '\'':
+			quote = s[i]
+		case s[i] == '>':
+			return i + 1
+		}
+	}
+	return 0
+}
+
+func htmlMarkupUnit(text string) truncateUnit {
+	u := truncateUnit{text: text, markup: true}
+	if strings.HasPrefix(text, "<!") {
+		u.void = true
+		return u
+	}
+	name := text[1:]
+	if strings.HasPrefix(name, "/") {
+		u.closing = true
+		name = name[1:]
+	}
+	end := 0
+	for end < len(name) && (isASCIILetter(name[end]) || isASCIIDigit(name[end])) {
+		end++
+	}
+	u.tag = strings.ToLower(name[:end])
+	u.void = htmlVoidElements[u.tag] || strings.HasSuffix(text, "/>")
+	return u
+}
+
+// htmlEntityLen returns the byte length of a named or numeric character
+// reference at the start of s, or 0 when s does not start with one.
+func htmlEntityLen(s string) int {
+	i := 1
+	if i < len(s) && s[i] == '#' {
+		i++
+		hex := i < len(s) && (s[i] == 'x' || s[i] == 'X')
+		if hex {
+			i++
+		}
+		start := i
+		for i < len(s) && (isASCIIDigit(s[i]) || hex && isASCIIHexLetter(s[i])) {
+			i++
+		}
+		if i == start {
+			return 0
+		}
+	} else {
+		start := i
+		for i < len(s) && (isASCIILetter(s[i]) || isASCIIDigit(s[i])) {
+			i++
+		}
+		if i == start {
+			return 0
+		}
+	}
+	if i < len(s) && s[i] == ';' {
+		return i + 1
+	}
+	return 0
+}
+
+func trackOpenElement(open []string, u truncateUnit) []string {
+	if u.visible() || u.tag == "" || u.void {
+		return open
+	}
+	if !u.closing {
+		return append(open, u.tag)
+	}
+	for i := len(open) - 1; i >= 0; i-- {
+		if open[i] == u.tag {
+			return open[:i]
+		}
+	}
+	return open
+}
+
+func isASCIILetter(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
+
+func isASCIIDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+func isASCIIHexLetter(c byte) bool {
+	return 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F'
+}
@@ -0,0 +1,117 @@
+package filter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTruncateWithOptionsZeroValueMatchesTruncate(t *testing.T) {
+	t.Parallel()
+
+	inputs := []string{"Hello, world!", "Hello world", "😊😊😊😊😊😊", "Hi", "", "ab\xffcd\xfe\xffef", "\xe2\x82 broken rune"}
+	ellipses := [][]string{nil, {"--"}, {""}, {"…"}}
+	for _, input := range inputs {
+		for _, ellipsis := range ellipses {
+			for maxLength := -1; maxLength <= 14; maxLength++ {
+				want := Truncate(input, maxLength, ellipsis...)
+				got := TruncateWithOptions(input, maxLength, TruncateOptions{}, ellipsis...)
+				require.Equal(t, want, got, "input %q, maxLength %d, ellipsis %v", input, maxLength, ellipsis)
+			}
+		}
+	}
+}
+
+func TestTruncateWithOptionsWordBoundary(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		input     string
+		maxLength int
+		opts      TruncateOptions
+		ellipsis  []string
+		want      string
+	}{
+		{"Backs Up To Word", "Hello beautiful world", 14, TruncateOptions{WordBoundary: true}, nil, "Hello..."},
+		{"Cut Already At Word End", "Hello world", 8, TruncateOptions{WordBoundary: true}, nil, "Hello..."},
+		{"Keeps Several Words", "The quick brown fox jumps", 20, TruncateOptions{WordBoundary: true}, nil, "The quick brown..."},
+		{"Trims Punctuation", "Hello, world and more", 12, TruncateOptions{WordBoundary: true}, nil, "Hello..."},
+		{"Preserves Separator", "Hello, world and more", 12, TruncateOptions{WordBoundary: true, PreserveSeparator: true}, nil, "Hello,..."},
+		{"Long First Word Falls Back", "Supercalifragilistic", 10, TruncateOptions{WordBoundary: true}, nil, "Superca..."},
+		{"Custom Ellipsis", "Hello beautiful world", 8, TruncateOptions{WordBoundary: true}, []string{"…"}, "Hello…"},
+		{"Multibyte Words", "héllo wörld ünïcode", 14, TruncateOptions{WordBoundary: true}, nil, "héllo wörld..."},
+		{"Fits", "Hello", 5, TruncateOptions{WordBoundary: true}, nil, "Hello"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := TruncateWithOptions(tt.input, tt.maxLength, tt.opts, tt.ellipsis...)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestTruncateWithOptionsHTML(t *testing.T) {
+	t.Parallel()
+
+	htmlOnly := TruncateOptions{HTML: true}
+	htmlWords := TruncateOptions{HTML: true, WordBoundary: true}
+
+	tests := []struct {
+		name      string
+		input     string
+		maxLength int
+		opts      TruncateOptions
+		want      string
+	}{
+		{"Closes Open Tags", "<p>Hello <b>beautiful</b> world</p>", 12, htmlOnly, "<p>Hello <b>bea...</b></p>"},
+		{"Word Boundary Drops Empty Tag", "<p>Hello <b>beautiful</b> world</p>", 12, htmlWords, "<p>Hello...</p>"},
+		{"Word Boundary Keeps Closed Tag", "<p><b>Hello</b> beautiful world</p>", 18, htmlWords, "<p><b>Hello</b> beautiful...</p>"},
+		{"Markup Does Not Count", "<p>Hi</p>", 5, htmlOnly, "<p>Hi</p>"},
+		{"Entity Counts As One Rune", "Tom &amp; Jerry forever", 8, htmlOnly, "Tom &amp;..."},
+		{"Numeric Entity Not Split", "caf&#233; au lait", 7, htmlOnly, "caf&#233;..."},
+		{"Void And Self Closing", "a<br>b<img src=x/>cdefgh", 5, htmlOnly, "a<br>b..."},
+		{"Quoted Angle Bracket", `<a href="x>y">link text here</a>`, 7, htmlOnly, `<a href="x>y">link...</a>`},
+		{"Comments Do Not Count", "<!-- note -->Hello world", 8, htmlOnly, "<!-- note -->Hello..."},
+		{"Stray Less Than Is Text", "1 < 2 and 3 > 2", 8, htmlOnly, "1 < 2..."},
+		{"Nested Elements", "<ul><li><i>one two three</i></li></ul>", 10, htmlWords, "<ul><li><i>one two...</i></li></ul>"},
+		{"Uppercase Tags", "<DIV>Hello world</DIV>", 8, htmlOnly, "<DIV>Hello...</div>"},
+		{"Unmatched End Tag", "x</span>Hello world", 8, htmlOnly, "x</span>Hell..."},
+		{"Script Costs Nothing", "<script>var a = 1 < 2;</script>Hello world", 8, htmlOnly, "<script>var a = 1 < 2;</script>Hello..."},
+		{"Script After Cut Dropped", "Hello world<script>if (a<b) {}</script> and more", 8, htmlOnly, "Hello..."},
+		{"Style Not Cut", "<p>Hi <style>p { color: red }</style>there friend</p>", 10, htmlOnly, "<p>Hi <style>p { color: red }</style>ther...</p>"},
+		{"Script Contents Not Tags", "<script>document.write('<b>')</script>Hello world", 8, htmlOnly, "<script>document.write('<b>')</script>Hello..."},
+		{"Uppercase Script End Tag", "<SCRIPT>x&amp;y</SCRIPT >Hello world", 8, htmlOnly, "<SCRIPT>x&amp;y</SCRIPT >Hello..."},
+		{"Unclosed Script", "Hello world<script>var long = 'text that never ends';", 8, htmlOnly, "Hello..."},
+		{"Unclosed Script Fits", "Hi<script>var long = 'text that never ends';", 8, htmlOnly, "Hi<script>var long = 'text that never ends';"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := TruncateWithOptions(tt.input, tt.maxLength, tt.opts)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func FuzzTruncateWithOptions(f *testing.F) {
+	f.Add("<p>Hello <b>world</b></p>", 8, true, true)
+	f.Add("Tom &amp; Jerry", 5, true, false)
+	f.Add("plain text", 3, false, true)
+	f.Add("ab\xffcd\xfe", 4, false, false)
+	f.Add("<style>a<b</style>text", 3, true, false)
+	f.Fuzz(func(t *testing.T, input string, maxLength int, html, words bool) {
+		if got, want := TruncateWithOptions(input, maxLength, TruncateOptions{}), Truncate(input, maxLength); got != want {
+			t.Fatalf("TruncateWithOptions(%q, %d) = %q, Truncate = %q", input, maxLength, got, want)
+		}
+		got := TruncateWithOptions(input, maxLength, TruncateOptions{HTML: html, WordBoundary: words})
+		if !html && Length(got) > max(maxLength, 0) {
+			t.Fatalf("TruncateWithOptions(%q, %d) = %q exceeds limit", input, maxLength, got)
+		}
+	})
+}
@@ -0,0 +1,203 @@
+package filter
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// ParseURL splits a URL into its parts, returned as a map so templates can
+// read them with Extract:
+//
+//	scheme       "https"
+//	user         user name, without any password
+//	host         host with port, "example.com:8080"
+//	hostname     host without port or IPv6 brackets
+//	port         "8080", or "" when absent
+//	path         decoded path, "/a b"
+//	query        map[string]string with the first value of each parameter
+//	queryValues  map[string][]string with every value, in order
+//	fragment     decoded fragment, without "#"
+//
+// Missing parts are "". A URL or query string that cannot be parsed, such
+// as one with an invalid percent escape or port, returns
+// *Error{Kind: KindFormat}.
+func ParseURL(input string) (map[string]any, error) {
+	u, err := url.Parse(input)
+	if err != nil {
+		return nil, formatErr("ParseURL", err)
+	}
+	values, err := url.ParseQuery(u.RawQuery)
+	if err != nil {
+		return nil, formatErr("ParseURL", err)
+	}
+	first := make(map[string]string, len(values))
+	for k, v := range values {
+		first[k] = v[0]
+	}
+	return map[string]any{
+		"scheme":      u.Scheme,
+		"user":        u.User.Username(),
+		"host":        u.Host,
+		"hostname":    u.Hostname(),
+		"port":        u.Port(),
+		"path":        u.Path,
+		"query":       first,
+		"queryValues": map[string][]string(values),
+		"fragment":    u.Fragment,
+	}, nil
+}
+
+// BuildQuery encodes params, a map with string keys, as a URL query string
+// without the leading "?". Keys are sorted so equal input always gives the
+// same output. A slice or array value adds the key once per element, nil
+// values and elements are left out, and other values are formatted with
+// fmt.Sprint. Keys and values are escaped as URLEncode does.
+//
+// params that is not a map with string keys returns
+// *Error{Kind: KindInvalidInput}.
+func BuildQuery(params any) (string, error) {
+	v := reflect.Indirect(reflect.ValueOf(params))
+	if v.Kind() != reflect.Map || v.Type().Key().Kind() != reflect.String {
+		return "", invalidInput("BuildQuery", fmt.Errorf("expected map with string keys, got %T", params))
+	}
+	values := make(url.Values, v.Len())
+	iter := v.MapRange()
+	for iter.Next() {
+		key := iter.Key().String()
+		value := iter.Value()
+		for value.Kind() == reflect.Interface && !value.IsNil() {
+			value = value.Elem()
+		}
+		switch value.Kind() {
+		case reflect.Slice, reflect.Array:
+			for i := range value.Len() {
+				if item := value.Index(i).Interface(); item != nil {
+					values.Add(key, fmt.Sprint(item))
+				}
+			}
+		case reflect.Invalid:
+			// A nil value adds nothing.
+		default:
+			if item := value.Interface(); item != nil {
+				values.Add(key, fmt.Sprint(item))
+			}
+		}
+	}
+	return values.Encode(), nil
+}
+
+// SetQueryParam sets parameter key of rawURL to value. The first occurrence
+// of key is replaced in place and any others are removed; a missing key is
+// appended. Other parameters keep their order and encoding.
+//
+// An unparsable URL returns *Error{Kind: KindFormat}.
+func SetQueryParam(rawURL, key, value string) (string, error) {
+	return editQuery("SetQueryParam", rawURL, key, &value)
+}
+
+// RemoveQueryParam removes every occurrence of parameter key from rawURL.
+// Other parameters keep their order and encoding, and the "?" is dropped
+// when no parameters remain.
+//
+// An unparsable URL returns *Error{Kind: KindFormat}.
+func RemoveQueryParam(rawURL, key string) (string, error) {
+	return editQuery("RemoveQueryParam", rawURL, key, nil)
+}
+
+// editQuery rewrites the query of rawURL pair by pair, so pairs other than
+// key are kept byte for byte. A nil value removes key.
+func editQuery(op, rawURL, key string, value *string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", formatErr(op, err)
+	}
+	var pairs []string
+	set := value == nil
+	for pair := range strings.SplitSeq(u.RawQuery, "&") {
+		if pair == "" {
+			continue
+		}
+		rawName, _, _ := strings.Cut(pair, "=")
+		name, err := url.QueryUnescape(rawName)
+		if err != nil {
+			return "", formatErr(op, err)
+		}
+		switch {
+		case name != key:
+			pairs = append(pairs, pair)
+		case !set:
+			pairs = append(pairs, url.QueryEscape(key)+"="+url.QueryEscape(*value))
+			set = true
+		}
+	}
+	if !set {
+		pairs = append(pairs, url.QueryEscape(key)+"="+url.QueryEscape(*value))
+	}
+	u.RawQuery = strings.Join(pairs, "&")
+	u.ForceQuery = false
+	return u.String(), nil
+}
+
+// JoinURLPath appends path elements to the path of base with exactly one
+// "/" between them. Each element is split on "/", empty and "." segments
+// are dropped, and the rest are escaped as URLPathEscape does, so "?" and
+// "#" cannot start a query or fragment. The result ends with "/" when the
+// last non-empty element does, or, with no elements, when base does. Query
+// and fragment of base are kept.
+//
+// A ".." segment would climb out of base, so it returns
+// *Error{Kind: KindInvalidInput}; an unparsable base returns
+// *Error{Kind: KindFormat}.
+func JoinURLPath(base string, elems ...string) (string, error) {
+	u, err := url.Parse(base)
+	if err != nil {
+		return "", formatErr("JoinURLPath", err)
+	}
+	var b strings.Builder
+	b.WriteString(strings.TrimSuffix(u.EscapedPath(), "/"))
+	trailing := strings.HasSuffix(u.Path, "/")
+	for _, elem := range elems {
+		if elem == "" {
+			continue
+		}
+		for segment := range strings.SplitSeq(elem, "/") {
+			switch segment {
+			case "", ".":
+				continue
+			case "..":
+				return "", invalidInput("JoinURLPath", fmt.Errorf("%q climbs out of the base path", elem))
+			}
+			b.WriteByte('/')
+			b.WriteString(url.PathEscape(segment))
+		}
+		trailing = strings.HasSuffix(elem, "/")
+	}
+	if trailing {
+		b.WriteByte('/')
+	}
+	escaped := b.String()
+	// escaped was built from valid escapes, so unescaping cannot fail.
+	u.Path, _ = url.PathUnescape(escaped)
+	u.RawPath = escaped
+	return u.String(), nil
+}
+
+// ResolveURL resolves ref against base as a browser resolves a link, per
+// RFC 3986 section 5: an absolute ref is returned as is, "/x" replaces the
+// path, "x" and "../x" are relative to the directory of base, and "?q" or
+// "#f" keep the path of base. "." and ".." segments are removed.
+//
+// An unparsable base or ref returns *Error{Kind: KindFormat}.
+func ResolveURL(base, ref string) (string, error) {
+	b, err := url.Parse(base)
+	if err != nil {
+		return "", formatErr("ResolveURL", err)
+	}
+	r, err := url.Parse(ref)
+	if err != nil {
+		return "", formatErr("ResolveURL", err)
+	}
+	return b.ResolveReference(r).String(), nil
+}
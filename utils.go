@@ -1,6 +1,7 @@
 package filter
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math"
@@ -9,7 +10,8 @@ import (
 	"strings"
 )
 
-// toFloat64 converts numeric input to float64. Strings are parsed as decimals.
+// toFloat64 converts numeric input to float64. Strings and json.Number are
+// parsed as decimals.
 // Returns *Error{Kind:KindInvalidInput} on unsupported types and
 // *Error{Kind:KindFormat} on unparseable strings.
 func toFloat64(input any) (float64, error) {
@@ -44,6 +46,12 @@ func toFloat64(input any) (float64, error) {
 			return 0, formatErr("toFloat64", err)
 		}
 		return f, nil
+	case json.Number:
+		f, err := strconv.ParseFloat(string(v), 64)
+		if err != nil {
+			return 0, formatErr("toFloat64", err)
+		}
+		return f, nil
 	default:
 		return 0, invalidInput("toFloat64", nil)
 	}
@@ -77,6 +85,8 @@ func toInt64Exact(op string, input any) (int64, error) {
 		return floatToInt64Exact(op, v)
 	case string:
 		return stringToInt64Exact(op, v)
+	case json.Number:
+		return stringToInt64Exact(op, string(v))
 	default:
 		return 0, invalidInput(op, nil)
 	}
@@ -0,0 +1,165 @@
+package filter
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// DisplayWidth returns the number of monospace terminal columns input
+// occupies. Width is measured per grapheme cluster:
+//
+//   - East Asian Wide and Fullwidth characters and emoji with default emoji
+//     presentation take two columns, as does a character followed by the
+//     emoji variation selector U+FE0F. A regional-indicator flag is one
+//     two-column cluster.
+//   - Control characters, combining marks, and format characters such as
+//     ZWJ take zero columns.
+//   - Everything else, including East Asian Ambiguous characters, takes one.
+//
+// Ambiguous characters are always one column wide; this package does not
+// read a locale to decide otherwise.
+func DisplayWidth(input string) int {
+	width := 0
+	for len(input) > 0 {
+		n := nextGraphemeLen(input)
+		width += clusterWidth(input[:n])
+		input = input[n:]
+	}
+	return width
+}
+
+// PadLeft right-aligns input in a field of width columns by prepending pad
+// (default " ") as measured by DisplayWidth. Columns a wide pad cannot fill
+// exactly are filled with spaces. Input already at least width columns wide
+// is returned unchanged.
+func PadLeft(input string, width int, pad ...string) string {
+	fill := width - DisplayWidth(input)
+	if fill <= 0 {
+		return input
+	}
+	return padding(fill, pad) + input
+}
+
+// PadRight left-aligns input in a field of width columns by appending pad
+// (default " "). It follows the same rules as PadLeft.
+func PadRight(input string, width int, pad ...string) string {
+	fill := width - DisplayWidth(input)
+	if fill <= 0 {
+		return input
+	}
+	return input + padding(fill, pad)
+}
+
+// Center centers input in a field of width columns, padding both sides with
+// pad (default " "). When the padding cannot be split evenly the extra
+// column goes on the right. It follows the same rules as PadLeft.
+func Center(input string, width int, pad ...string) string {
+	fill := width - DisplayWidth(input)
+	if fill <= 0 {
+		return input
+	}
+	left := fill / 2
+	return padding(left, pad) + input + padding(fill-left, pad)
+}
+
+// TruncateWidth shortens input to at most maxWidth columns, including the
+// ellipsis, without splitting a grapheme cluster. When the next cluster is a
+// two-column character that would overflow, the result may be one column
+// narrower than maxWidth. The ellipsis defaults to "..." as in Truncate.
+func TruncateWidth(input string, maxWidth int, ellipsis ...string) string {
+	omission := truncateOmission(ellipsis)
+	if maxWidth <= 0 {
+		return ""
+	}
+	if DisplayWidth(input) <= maxWidth {
+		return input
+	}
+	omissionWidth := DisplayWidth(omission)
+	if maxWidth <= omissionWidth {
+		return takeWidth(omission, maxWidth)
+	}
+	return takeWidth(input, maxWidth-omissionWidth) + omission
+}
+
+// takeWidth returns the longest prefix of s made of whole grapheme clusters
+// that fits in width columns.
+func takeWidth(s string, width int) string {
+	used, end := 0, 0
+	for end < len(s) {
+		n := nextGraphemeLen(s[end:])
+		w := clusterWidth(s[end : end+n])
+		if used+w > width {
+			break
+		}
+		used += w
+		end += n
+	}
+	return s[:end]
+}
+
+func padding(columns int, pad []string) string {
+	unit := " "
+	if len(pad) > 0 && pad[0] != "" {
+		unit = pad[0]
+	}
+	unitWidth := DisplayWidth(unit)
+	if unitWidth <= 0 {
+		unit, unitWidth = " ", 1
+	}
+	return strings.Repeat(unit, columns/unitWidth) + strings.Repeat(" ", columns%unitWidth)
+}
+
+// clusterWidth returns the column width of a single grapheme cluster.
+func clusterWidth(cluster string) int {
+	width := 0
+	for _, r := range cluster {
+		if width == 0 {
+			width = runeWidth(r)
+			continue
+		}
+		if r == 0xFE0F {
+			return 2
+		}
+	}
+	return width
+}
+
+func runeWidth(r rune) int {
+	switch {
+	case r < 0x20 || r == 0x7F:
+		return 0
+	case r < 0x7F:
+		return 1
+	case r < 0xA0:
+		return 0
+	case r == 0x200D || r >= 0x1160 && r <= 0x11FF:
+		// ZWJ and Hangul medial vowels and final consonants join the
+		// preceding syllable.
+		return 0
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf):
+		return 0
+	case inRuneRanges(wideRanges[:], r):
+		return 2
+	default:
+		return 1
+	}
+}
+
+type runeRange struct {
+	lo, hi rune
+}
+
+func inRuneRanges(ranges []runeRange, r rune) bool {
+	_, found := slices.BinarySearchFunc(ranges, r, func(e runeRange, r rune) int {
+		switch {
+		case r < e.lo:
+			return 1
+		case r > e.hi:
+			return -1
+		default:
+			return 0
+		}
+	})
+	return found
+}
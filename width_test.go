@@ -0,0 +1,130 @@
+package filter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDisplayWidth(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input string
+		want  int
+	}{
+		{"Empty", "", 0},
+		{"ASCII", "hello", 5},
+		{"CJK", "你好", 4},
+		{"Hiragana", "こんにちは", 10},
+		{"Hangul Syllables", "한국어", 6},
+		{"Hangul Jamo", "\u1100\u1161\u11A8", 2},
+		{"Fullwidth Latin", "ＡＢＣ", 6},
+		{"Halfwidth Katakana", "ｶﾀｶﾅ", 4},
+		{"Mixed", "Go言語", 6},
+		{"Emoji", "\U0001F600", 2},
+		{"Skin Tone", thumbsMedium, 2},
+		{"ZWJ Family", familyZWJ, 2},
+		{"Flag", flagJP, 2},
+		{"Text Presentation", "❤", 1},
+		{"Emoji Variation Selector", "❤\uFE0F", 2},
+		{"Combining Accent", "caf" + eAcute, 4},
+		{"Ambiguous Is Narrow", "±§", 2},
+		{"Control Characters", "a\tb\x00", 2},
+		{"Zero Width Space", "a\u200Bb", 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tt.want, DisplayWidth(tt.input))
+		})
+	}
+}
+
+func TestPadding(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		fn   func(string, int, ...string) string
+		in   string
+		w    int
+		pad  []string
+		want string
+	}{
+		{"PadLeft ASCII", PadLeft, "42", 5, nil, "   42"},
+		{"PadLeft CJK", PadLeft, "你好", 6, nil, "  你好"},
+		{"PadLeft Custom Pad", PadLeft, "7", 3, []string{"0"}, "007"},
+		{"PadLeft Already Wide", PadLeft, "hello", 3, nil, "hello"},
+		{"PadRight ASCII", PadRight, "ab", 4, nil, "ab  "},
+		{"PadRight Emoji", PadRight, thumbsMedium, 4, []string{"."}, thumbsMedium + ".."},
+		{"PadRight Wide Pad Remainder", PadRight, "a", 4, []string{"－"}, "a－ "},
+		{"PadRight Zero Width Pad Falls Back", PadRight, "a", 3, []string{"\u0301"}, "a  "},
+		{"Center Even", Center, "ab", 6, nil, "  ab  "},
+		{"Center Odd Extra Right", Center, "ab", 5, []string{"*"}, "*ab**"},
+		{"Center CJK", Center, "中", 6, []string{"-"}, "--中--"},
+		{"Center Negative Width", Center, "ab", -1, nil, "ab"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := tt.fn(tt.in, tt.w, tt.pad...)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestPaddingAlignsColumns(t *testing.T) {
+	t.Parallel()
+
+	for _, cell := range []string{"name", "名前", "Zoë", familyZWJ + "!", "ｶﾀｶﾅ"} {
+		require.Equal(t, 8, DisplayWidth(PadRight(cell, 8)), "PadRight(%q)", cell)
+		require.Equal(t, 8, DisplayWidth(PadLeft(cell, 8)), "PadLeft(%q)", cell)
+		require.Equal(t, 8, DisplayWidth(Center(cell, 8)), "Center(%q)", cell)
+	}
+}
+
+func TestTruncateWidth(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		input    string
+		maxWidth int
+		ellipsis []string
+		want     string
+	}{
+		{"Fits", "hello", 5, nil, "hello"},
+		{"ASCII", "hello world", 8, nil, "hello..."},
+		{"CJK", "你好世界你好", 7, nil, "你好..."},
+		{"Wide Char Would Overflow", "你好世界你好", 8, nil, "你好..."},
+		{"Custom Ellipsis", "你好世界你好", 8, []string{"…"}, "你好世…"},
+		{"Emoji Not Split", familyZWJ + familyZWJ + familyZWJ, 5, []string{"…"}, familyZWJ + familyZWJ + "…"},
+		{"Ellipsis Wider Than Limit", "hello world", 2, nil, ".."},
+		{"Single Column Ellipsis", "hello world", 1, []string{"…"}, "…"},
+		{"Empty Ellipsis", "hello world", 5, []string{""}, "hello"},
+		{"Zero Width", "hello", 0, nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := TruncateWidth(tt.input, tt.maxWidth, tt.ellipsis...)
+			require.Equal(t, tt.want, got)
+			require.LessOrEqual(t, DisplayWidth(got), max(tt.maxWidth, 0))
+		})
+	}
+}
+
+func BenchmarkDisplayWidth(b *testing.B) {
+	input := "Go言語 " + familyZWJ + " hello 你好世界 " + flagJP
+	for b.Loop() {
+		DisplayWidth(input)
+	}
+}
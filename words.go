@@ -0,0 +1,203 @@
+package filter
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// defaultWordsPerMinute is the reading speed ReadingTime assumes when the
+// caller gives none.
+const defaultWordsPerMinute = 200
+
+// textSpan is a byte range of a string, such as one word or match.
+type textSpan struct {
+	start, end int
+}
+
+// wordSpans returns the words of s in order. This is the one definition of
+// a word shared by WordCount, ReadingTime, and TruncateWords: a maximal run
+// of runes that are neither white space nor punctuation, where an
+// apostrophe or hyphen between two such runes joins them, so "don't" and
+// "well-known" are single words while "a - b" is two.
+func wordSpans(s string) []textSpan {
+	var spans []textSpan
+	start := -1
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case isWordPart(r):
+			if start < 0 {
+				start = i
+			}
+		case start >= 0 && isWordJoiner(r) && startsWithWordPart(s[i+size:]):
+			// The joiner stays inside the current word.
+		case start >= 0:
+			spans = append(spans, textSpan{start, i})
+			start = -1
+		}
+		i += size
+	}
+	if start >= 0 {
+		spans = append(spans, textSpan{start, len(s)})
+	}
+	return spans
+}
+
+func isWordPart(r rune) bool {
+	return !unicode.IsSpace(r) && !unicode.IsPunct(r)
+}
+
+// isWordJoiner reports whether r joins two words into one: the ASCII and
+// typographic apostrophes and the ASCII and Unicode hyphens.
+func isWordJoiner(r rune) bool {
+	switch r {
+	case '\'', '’', '-', '‐':
+		return true
+	}
+	return false
+}
+
+func startsWithWordPart(s string) bool {
+	r, _ := utf8.DecodeRuneInString(s)
+	return s != "" && isWordPart(r)
+}
+
+// WordCount returns the number of words in input. A word is a run of
+// characters that are neither white space nor punctuation; an apostrophe
+// or hyphen between two such characters does not end it, so "don't" and
+// "well-known" count once. Numbers and emoji count as words. Scripts
+// written without spaces, such as Chinese, count each run between
+// punctuation as one word.
+func WordCount(input string) int {
+	return len(wordSpans(input))
+}
+
+// CharCount returns the number of user-perceived characters (grapheme
+// clusters, as GraphemeLength counts them) in input. With
+// excludeWhitespace, clusters that are white space, including line breaks,
+// are not counted.
+func CharCount(input string, excludeWhitespace bool) int {
+	count := 0
+	for len(input) > 0 {
+		n := nextGraphemeLen(input)
+		if !excludeWhitespace || strings.TrimSpace(input[:n]) != "" {
+			count++
+		}
+		input = input[n:]
+	}
+	return count
+}
+
+// SentenceSplit splits input into sentences, each trimmed of surrounding
+// white space. A sentence ends after a run of ".", "!", "?", or "…",
+// together with any closing quotes or brackets, that is followed by white
+// space and then a character that is not a lower-case letter, or by the
+// end of input; "。", "！", and "？" end a sentence wherever they appear.
+// So "3.14" and "e.g. this" do not split, though an abbreviation before a
+// capitalized word, as in "Dr. Smith", does. Empty input yields an empty
+// slice.
+func SentenceSplit(input string) []string {
+	out := []string{}
+	start := 0
+	for i := 0; i < len(input); {
+		r, size := utf8.DecodeRuneInString(input[i:])
+		i += size
+		if !isSentenceEnd(r) {
+			continue
+		}
+		fullStop := isFullwidthSentenceEnd(r)
+		for i < len(input) {
+			r, size = utf8.DecodeRuneInString(input[i:])
+			if !isSentenceEnd(r) && !isSentenceCloser(r) {
+				break
+			}
+			fullStop = fullStop || isFullwidthSentenceEnd(r)
+			i += size
+		}
+		if !fullStop && !sentenceFollows(input[i:]) {
+			continue
+		}
+		if sentence := strings.TrimSpace(input[start:i]); sentence != "" {
+			out = append(out, sentence)
+		}
+		start = i
+	}
+	if sentence := strings.TrimSpace(input[start:]); sentence != "" {
+		out = append(out, sentence)
+	}
+	return out
+}
+
+// sentenceFollows reports whether rest, the text after a sentence
+// terminator, starts a new sentence: white space and then anything but a
+// lower-case letter.
+func sentenceFollows(rest string) bool {
+	trimmed := strings.TrimLeftFunc(rest, unicode.IsSpace)
+	if len(trimmed) == len(rest) {
+		return false
+	}
+	r, _ := utf8.DecodeRuneInString(trimmed)
+	return !unicode.IsLower(r)
+}
+
+func isSentenceEnd(r rune) bool {
+	switch r {
+	case '.', '!', '?', '…', '‽':
+		return true
+	}
+	return isFullwidthSentenceEnd(r)
+}
+
+func isFullwidthSentenceEnd(r rune) bool {
+	switch r {
+	case '。', '！', '？', '｡':
+		return true
+	}
+	return false
+}
+
+func isSentenceCloser(r rune) bool {
+	switch r {
+	case '"', '\'', ')', ']', '’', '”', '»', '」', '』', '）':
+		return true
+	}
+	return false
+}
+
+// ParagraphSplit splits input into paragraphs at blank lines, lines that
+// are empty or hold only white space. Lines within a paragraph are kept as
+// they are and joined with "\n", whatever line break the input used.
+// Leading and trailing blank lines produce no paragraph, and input without
+// any text yields an empty slice.
+func ParagraphSplit(input string) []string {
+	out := []string{}
+	var lines []string
+	for _, line := range splitLines(input) {
+		if strings.TrimSpace(line) != "" {
+			lines = append(lines, line)
+			continue
+		}
+		if len(lines) > 0 {
+			out = append(out, strings.Join(lines, "\n"))
+			lines = lines[:0]
+		}
+	}
+	if len(lines) > 0 {
+		out = append(out, strings.Join(lines, "\n"))
+	}
+	return out
+}
+
+// ReadingTime returns the whole minutes needed to read input at
+// wordsPerMinute words per minute, as WordCount counts words, rounded up
+// so any text takes at least one minute. Text without words takes zero
+// minutes. A wordsPerMinute of zero or less uses 200, a common estimate
+// for adult readers of English.
+func ReadingTime(input string, wordsPerMinute int) int {
+	if wordsPerMinute <= 0 {
+		wordsPerMinute = defaultWordsPerMinute
+	}
+	words := WordCount(input)
+	return (words + wordsPerMinute - 1) / wordsPerMinute
+}
@@ -0,0 +1,197 @@
+package filter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWordCount(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input string
+		want  int
+	}{
+		{"Empty", "", 0},
+		{"Only Spaces", "  \t\n ", 0},
+		{"Simple", "Hello beautiful world", 3},
+		{"Punctuation Separates", "Hello,world!How are you?", 5},
+		{"Only Punctuation", "... -- !?", 0},
+		{"Apostrophe", "Don't stop, y'all", 3},
+		{"Typographic Apostrophe", "It’s fine", 2},
+		{"Trailing Apostrophe", "The dogs' bowls", 3},
+		{"Hyphenated", "A well-known, state-of-the-art tool", 4},
+		{"Spaced Dash", "this - that", 2},
+		{"Double Hyphen", "this--that", 2},
+		{"Numbers", "Version 3.14 has 2 fixes", 6},
+		{"Emoji", "🌟✨🌟 Sparkling stars", 3},
+		{"CJK Runs", "你好，世界 Hello", 3},
+		{"Accents", "Crème brûlée", 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tt.want, WordCount(tt.input))
+		})
+	}
+}
+
+func TestCharCount(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name              string
+		input             string
+		excludeWhitespace bool
+		want              int
+	}{
+		{"Empty", "", false, 0},
+		{"ASCII", "hello world", false, 11},
+		{"ASCII Without Whitespace", "hello world", true, 10},
+		{"Line Breaks Excluded", "a\r\nb\tc  ", true, 3},
+		{"Line Breaks Counted", "a\r\nb", false, 3},
+		{"Combining Mark", "e\u0301te\u0301", false, 3},
+		{"Emoji Sequence", "👨‍👩‍👧 family", true, 7},
+		{"Flag", "🇯🇵", false, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tt.want, CharCount(tt.input, tt.excludeWhitespace))
+		})
+	}
+}
+
+func TestSentenceSplit(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{"Empty", "", []string{}},
+		{"Only Spaces", "   ", []string{}},
+		{"No Terminator", "Hello world", []string{"Hello world"}},
+		{"Simple", "Hello world. How are you? Fine!", []string{"Hello world.", "How are you?", "Fine!"}},
+		{"Surrounding Space Trimmed", "  One.   Two.  ", []string{"One.", "Two."}},
+		{"Repeated Terminators", "Really?! Yes...", []string{"Really?!", "Yes..."}},
+		{"Closing Quote", `He said "stop." Then he left.`, []string{`He said "stop."`, "Then he left."}},
+		{"Closing Bracket", "It works (mostly.) Try it.", []string{"It works (mostly.)", "Try it."}},
+		{"Decimal Number", "Pi is 3.14 roughly. Yes.", []string{"Pi is 3.14 roughly.", "Yes."}},
+		{"Lower Case Continues", "Use tools, e.g. this one. Done.", []string{"Use tools, e.g. this one.", "Done."}},
+		{"Ellipsis Character", "Wait… Then go.", []string{"Wait…", "Then go."}},
+		{"Line Break", "First line.\nSecond line.", []string{"First line.", "Second line."}},
+		{"Digit Starts Sentence", "Count them. 3 remain.", []string{"Count them.", "3 remain."}},
+		{"Fullwidth", "你好。世界！好吗？", []string{"你好。", "世界！", "好吗？"}},
+		{"No Space After Period", "example.com is up.", []string{"example.com is up."}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tt.want, SentenceSplit(tt.input))
+		})
+	}
+}
+
+func TestParagraphSplit(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{"Empty", "", []string{}},
+		{"Only Blank Lines", "\n \n\t\n", []string{}},
+		{"Single", "one\ntwo", []string{"one\ntwo"}},
+		{"Two", "one\ntwo\n\nthree", []string{"one\ntwo", "three"}},
+		{"Several Blank Lines", "one\n\n\n \nthree", []string{"one", "three"}},
+		{"Leading And Trailing Blank Lines", "\n\none\n\n", []string{"one"}},
+		{"CRLF", "one\r\ntwo\r\n\r\nthree\r\n", []string{"one\ntwo", "three"}},
+		{"Indentation Kept", "  code\n    more\n\ntext", []string{"  code\n    more", "text"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tt.want, ParagraphSplit(tt.input))
+		})
+	}
+}
+
+func TestReadingTime(t *testing.T) {
+	t.Parallel()
+
+	words := func(n int) string { return strings.Repeat("word ", n) }
+	tests := []struct {
+		name           string
+		input          string
+		wordsPerMinute int
+		want           int
+	}{
+		{"Empty", "", 200, 0},
+		{"Only Punctuation", "...", 200, 0},
+		{"One Word", "hello", 200, 1},
+		{"Exact Minutes", words(400), 200, 2},
+		{"Rounded Up", words(401), 200, 3},
+		{"Custom Speed", words(1000), 250, 4},
+		{"Default Speed", words(1000), 0, 5},
+		{"Negative Speed Uses Default", words(201), -5, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tt.want, ReadingTime(tt.input, tt.wordsPerMinute))
+		})
+	}
+}
+
+func FuzzWordSpans(f *testing.F) {
+	f.Add("Don't stop, well-known 3.14 🌟")
+	f.Add("a - b -- c' 'd")
+	f.Add("\xff-\xfe")
+	f.Fuzz(func(t *testing.T, input string) {
+		spans := wordSpans(input)
+		prev := 0
+		for _, span := range spans {
+			if span.start < prev || span.end <= span.start {
+				t.Fatalf("wordSpans(%q) = %v: spans overlap or are empty", input, spans)
+			}
+			word := input[span.start:span.end]
+			first, last := []rune(word)[0], []rune(word)[len([]rune(word))-1]
+			if !isWordPart(first) || !isWordPart(last) {
+				t.Fatalf("wordSpans(%q) word %q starts or ends with a separator", input, word)
+			}
+			for _, r := range input[prev:span.start] {
+				if isWordPart(r) {
+					t.Fatalf("wordSpans(%q) skipped word rune %q", input, r)
+				}
+			}
+			prev = span.end
+		}
+		for _, r := range input[prev:] {
+			if isWordPart(r) {
+				t.Fatalf("wordSpans(%q) skipped trailing word rune %q", input, r)
+			}
+		}
+
+		if n := len(spans); n > 0 {
+			if got := TruncateWords(input, n, ""); got != input {
+				t.Fatalf("TruncateWords(%q, %d) = %q, want input unchanged", input, n, got)
+			}
+			if n > 1 {
+				if got := WordCount(TruncateWords(input, n-1, "")); got != n-1 {
+					t.Fatalf("WordCount(TruncateWords(%q, %d)) = %d", input, n-1, got)
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,125 @@
+package filter
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// WrapOptions configures WrapWithOptions. The zero value behaves exactly
+// like Wrap.
+type WrapOptions struct {
+	// BreakLongWords splits a word longer than a whole line across lines.
+	// Without it such a word sits alone on an overlong line.
+	BreakLongWords bool
+	// PreserveBlankLines keeps every blank line of the input, including
+	// leading and trailing ones. Without it paragraphs are separated by
+	// exactly one blank line.
+	PreserveBlankLines bool
+	// Prefix starts every output line, as in "> " for quoted replies. It
+	// counts toward the width. Blank lines get the prefix without its
+	// trailing whitespace.
+	Prefix string
+	// Indent starts every line of a paragraph after its first, following
+	// Prefix, to form a hanging indent. It counts toward the width.
+	Indent string
+}
+
+// Wrap reflows input into lines of at most width runes, counted as Length
+// counts them. Lines within a paragraph are joined and re-broken at
+// whitespace; paragraphs are separated by blank lines, and runs of
+// whitespace collapse to one space. A word longer than width is kept whole
+// on its own line. A width of zero or less puts each paragraph on a single
+// line. Lines are separated by "\n" whatever line breaks the input used.
+func Wrap(input string, width int) string {
+	return WrapWithOptions(input, width, WrapOptions{})
+}
+
+// WrapWithOptions is Wrap with the long-word, blank-line, prefix, and
+// hanging-indent behavior selected by opts.
+func WrapWithOptions(input string, width int, opts WrapOptions) string {
+	blankLine := strings.TrimRight(opts.Prefix, " \t")
+	continuation := opts.Prefix + opts.Indent
+
+	var out []string
+	var words []string
+	separate := false
+	flush := func() {
+		if len(words) == 0 {
+			return
+		}
+		if separate {
+			out = append(out, blankLine)
+			separate = false
+		}
+		out = append(out, wrapParagraph(words, width, opts.Prefix, continuation, opts.BreakLongWords)...)
+		words = words[:0]
+	}
+
+	for _, line := range splitLines(input) {
+		if strings.TrimSpace(line) != "" {
+			words = append(words, strings.Fields(line)...)
+			continue
+		}
+		flush()
+		if opts.PreserveBlankLines {
+			out = append(out, blankLine)
+		} else if len(out) > 0 {
+			separate = true
+		}
+	}
+	flush()
+	return strings.Join(out, "\n")
+}
+
+// wrapParagraph greedily fills lines with words. The first line starts with
+// first and the rest with rest.
+func wrapParagraph(words []string, width int, first, rest string, breakLongWords bool) []string {
+	var lines []string
+	var line strings.Builder
+	lead := first
+	used, content := 0, false
+
+	// newLine ends the current line, if it has any words, and starts the
+	// next one with its lead.
+	newLine := func() {
+		if content {
+			lines = append(lines, line.String())
+			lead = rest
+		}
+		line.Reset()
+		line.WriteString(lead)
+		used, content = utf8.RuneCountInString(lead), false
+	}
+
+	newLine()
+	for _, word := range words {
+		n := utf8.RuneCountInString(word)
+		if content && width > 0 && used+1+n > width {
+			newLine()
+		}
+		if breakLongWords && width > 0 && used+n > width {
+			for n > 0 && used+n > width {
+				chunk := max(width-used, 1)
+				cut := prefixLen(word, chunk)
+				line.WriteString(word[:cut])
+				content = true
+				newLine()
+				word, n = word[cut:], n-chunk
+			}
+			if n == 0 {
+				continue
+			}
+		}
+		if content {
+			line.WriteByte(' ')
+			used++
+		}
+		line.WriteString(word)
+		used += n
+		content = true
+	}
+	if content {
+		lines = append(lines, line.String())
+	}
+	return lines
+}
@@ -0,0 +1,92 @@
+package filter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrap(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input string
+		width int
+		want  string
+	}{
+		{"Empty", "", 10, ""},
+		{"Fits", "hello world", 11, "hello world"},
+		{"Breaks At Whitespace", "the quick brown fox jumps over the lazy dog", 15, "the quick brown\nfox jumps over\nthe lazy dog"},
+		{"Reflows Paragraph Lines", "the quick\nbrown fox\njumps", 20, "the quick brown fox\njumps"},
+		{"Collapses Whitespace", "  the   quick \t brown  ", 20, "the quick brown"},
+		{"Separates Paragraphs", "one two three\n\n\n\nfour five", 8, "one two\nthree\n\nfour\nfive"},
+		{"Drops Leading And Trailing Blank Lines", "\n\nhello\n\n", 10, "hello"},
+		{"Long Word Kept Whole", "a supercalifragilistic word", 10, "a\nsupercalifragilistic\nword"},
+		{"Counts Runes", "héllo wörld ünïcode", 11, "héllo wörld\nünïcode"},
+		{"CRLF And CR Line Breaks", "one\r\ntwo\r\rthree", 20, "one two\n\nthree"},
+		{"Zero Width Joins Paragraph", "one\ntwo three", 0, "one two three"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tt.want, Wrap(tt.input, tt.width))
+		})
+	}
+}
+
+func TestWrapWithOptions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input string
+		width int
+		opts  WrapOptions
+		want  string
+	}{
+		{"Break Long Words", "a supercalifragilistic word", 10, WrapOptions{BreakLongWords: true}, "a\nsupercalif\nragilistic\nword"},
+		{"Break Long Word Remainder Shares Line", "abcdefghijkl mn", 5, WrapOptions{BreakLongWords: true}, "abcde\nfghij\nkl mn"},
+		{"Break Exact Multiple", "abcdefghij x", 5, WrapOptions{BreakLongWords: true}, "abcde\nfghij\nx"},
+		{"Break Multibyte Word", "ééééééé", 3, WrapOptions{BreakLongWords: true}, "ééé\nééé\né"},
+		{"Preserve Blank Lines", "\none\n\n\ntwo\n", 10, WrapOptions{PreserveBlankLines: true}, "\none\n\n\ntwo\n"},
+		{"Quote Prefix", "the quick brown fox\n\njumps over", 12, WrapOptions{Prefix: "> "}, "> the quick\n> brown fox\n>\n> jumps over"},
+		{"Hanging Indent", "1. the quick brown fox jumps", 12, WrapOptions{Indent: "   "}, "1. the quick\n   brown fox\n   jumps"},
+		{"Prefix And Indent", "- one two three four", 10, WrapOptions{Prefix: "# ", Indent: "  "}, "# - one\n#   two\n#   three\n#   four"},
+		{"Prefix Wider Than Width Breaks Per Rune", "abc", 2, WrapOptions{Prefix: ">>>", BreakLongWords: true}, ">>>a\n>>>b\n>>>c"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tt.want, WrapWithOptions(tt.input, tt.width, tt.opts))
+		})
+	}
+}
+
+func FuzzWrap(f *testing.F) {
+	f.Add("the quick brown fox\n\njumps over the lazy dog", 10, true, "> ")
+	f.Add("supercalifragilistic ünïcode", 4, false, "")
+	f.Fuzz(func(t *testing.T, input string, width int, breakLong bool, prefix string) {
+		width = width%200 + 1
+		if width <= 0 {
+			width += 200
+		}
+		if got, want := strings.Fields(Wrap(input, width)), strings.Fields(input); strings.Join(got, " ") != strings.Join(want, " ") {
+			t.Fatalf("Wrap(%q, %d) changed the words: %q", input, width, got)
+		}
+		got := WrapWithOptions(input, width, WrapOptions{BreakLongWords: breakLong, Prefix: prefix})
+		if !breakLong || Length(prefix) >= width {
+			return
+		}
+		for _, line := range strings.Split(got, "\n") {
+			if Length(line) > width {
+				t.Fatalf("Wrap(%q, %d) produced overlong line %q", input, width, line)
+			}
+		}
+	})
+}